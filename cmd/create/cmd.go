@@ -2,15 +2,19 @@ package create
 
 import (
 	"github.com/jordigilh/korn/cmd/create/release"
+	"github.com/jordigilh/korn/cmd/create/snapshot"
+	"github.com/jordigilh/korn/cmd/create/stream"
 	"github.com/urfave/cli/v3"
 )
 
 func Command() *cli.Command {
 	return &cli.Command{
 		Name:  "create",
-		Usage: "create release",
+		Usage: "create release|snapshot|stream",
 		Commands: []*cli.Command{
 			release.CreateCommand(),
+			snapshot.CreateCommand(),
+			stream.CreateCommand(),
 		},
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/jordigilh/korn/cmd/flags"
 	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	"github.com/sirupsen/logrus"
@@ -19,7 +20,7 @@ import (
 )
 
 var (
-	korn = konflux.Korn{WaitForTimeout: 60, EnvironmentName: "staging"}
+	korn = konflux.Korn{WaitForTimeout: 60, EnvironmentName: "staging", Workers: konflux.DefaultWorkers}
 )
 
 func CreateCommand() *cli.Command {
@@ -34,6 +35,8 @@ func CreateCommand() *cli.Command {
 			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
 			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
 			korn.PodClient = ctx.Value(internal.PodmanCliCtxType).(internal.ImageClient)
+			korn.GitClient = ctx.Value(internal.GitCliCtxType).(internal.GitCommitVersioner)
+			korn.KornVersion, _ = ctx.Value(internal.VersionCtxType).(string)
 			korn.DynamicClient = ctx.Value(internal.DynamicCliCtxType).(dynamic.Interface)
 			return ctx, nil
 		},
@@ -68,18 +71,14 @@ func CreateCommand() *cli.Command {
 				Destination: &korn.ApplicationName,
 			},
 			&cli.StringFlag{
-				Name:    "environment",
-				Aliases: []string{"env"},
-				Usage:   "Example: -environment staging",
-				Validator: func(val string) error {
-					if val != "staging" && val != "production" {
-						return fmt.Errorf("invalid value %s: only 'staging' or 'production' supported", val)
-					}
-					return nil
-				},
+				Name:        "environment",
+				Aliases:     []string{"env"},
+				Usage:       "Environment of the release plan, as in its korn.redhat.io/environment label. Run 'korn get environment' to list the environments of the application. Example: -environment staging",
 				DefaultText: korn.EnvironmentName,
 				Destination: &korn.EnvironmentName,
 			},
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
 			&cli.StringFlag{
 				Name:        "snapshot",
 				Usage:       "Example: -snapshot my-app-snapshot-abc123",
@@ -94,6 +93,26 @@ func CreateCommand() *cli.Command {
 				DefaultText: strconv.FormatBool(korn.ForceRelease),
 				Destination: &korn.ForceRelease,
 			},
+			&cli.BoolFlag{
+				Name:        "compare-release",
+				Usage:       "Requires the release label of each component image to match the one in the bundle when selecting the snapshot candidate",
+				Value:       false,
+				DefaultText: strconv.FormatBool(korn.CompareReleaseLabel),
+				Destination: &korn.CompareReleaseLabel,
+			},
+			&cli.BoolFlag{
+				Name:        "require-approval",
+				Usage:       "Only accepts snapshots that have been manually approved. Always enabled when the release plan is labeled with korn.redhat.io/require-approval=true",
+				Value:       false,
+				DefaultText: strconv.FormatBool(korn.RequireApproval),
+				Destination: &korn.RequireApproval,
+			},
+			&cli.StringFlag{
+				Name:        "branch",
+				Usage:       "Only selects candidate snapshots built from this git branch. Defaults to the branch in the application's korn.redhat.io/branch annotation. Example: -branch release-1.0",
+				DefaultText: korn.Branch,
+				Destination: &korn.Branch,
+			},
 			&cli.StringFlag{
 				Name:        "sha",
 				Usage:       "Example: -sha 245fca6109a1f32e5ded0f7e330a85401aa2704a",
@@ -121,6 +140,19 @@ func CreateCommand() *cli.Command {
 				Destination: &korn.WaitForTimeout,
 				Value:       korn.WaitForTimeout,
 			},
+			&cli.BoolFlag{
+				Name:        "record-verdicts",
+				Usage:       "Stores the candidacy verdict of each validated snapshot in its annotations",
+				Value:       false,
+				DefaultText: strconv.FormatBool(korn.RecordVerdicts),
+				Destination: &korn.RecordVerdicts,
+			},
+			&cli.DurationFlag{
+				Name:        "trust-verdicts",
+				Usage:       "Reuses the verdicts recorded in the snapshots by the same korn version and validation options within the given period instead of validating them again. Example: -trust-verdicts 24h",
+				Destination: &korn.TrustVerdictsFor,
+			},
+			flags.WorkersFlag(&korn),
 			&cli.StringFlag{
 				Name:    "releaseNotes",
 				Aliases: []string{"rn"},
@@ -157,10 +189,25 @@ func CreateCommand() *cli.Command {
 		},
 		Description: "Creates a release for a given application and environment",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
+			rpa, err := korn.PreflightReleasePlanAdmission()
+			if err != nil {
+				return err
+			}
+			if rpa != nil {
+				logrus.Infof("Release will be processed in target namespace %s by ReleasePlanAdmission %s with pipeline %s and policy %s", rpa.Namespace, rpa.Name, konflux.GetReleasePlanAdmissionPipeline(*rpa), rpa.Spec.Policy)
+			}
 			m, err := korn.GenerateReleaseManifest()
 			if err != nil {
 				return err
 			}
+			if rpa != nil {
+				if err := korn.ValidateReleaseMapping(*rpa, *m); err != nil {
+					return err
+				}
+			}
 			if len(korn.OutputType) > 0 {
 				s := mjson.NewSerializerWithOptions(
 					mjson.DefaultMetaFactory, nil, nil,
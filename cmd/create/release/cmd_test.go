@@ -135,6 +135,13 @@ var _ = Describe("Create Release Command", func() {
 				expectedError: false,
 				description:   "Should create release with custom timeout",
 			}),
+
+			Entry("release validating the snapshots sequentially", releaseTestCase{
+				name:          "release with one worker",
+				args:          []string{"--app", testutils.TestAppName, "--environment", "staging", "--workers", "1", "--wait=false", "--dryrun"},
+				expectedError: false,
+				description:   "Should create release validating one snapshot at a time",
+			}),
 		)
 	})
 
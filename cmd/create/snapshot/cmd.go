@@ -0,0 +1,126 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/urfave/cli/v3"
+	mjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	korn = konflux.Korn{}
+)
+
+func CreateCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "snapshot",
+		Aliases: []string{"snapshots"},
+		Usage:   "create override snapshots",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			korn.PodClient = ctx.Value(internal.PodmanCliCtxType).(internal.ImageClient)
+			korn.GitClient = ctx.Value(internal.GitCliCtxType).(internal.GitCommitVersioner)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "from",
+				Usage:       "Example: -from my-app-snapshot-abc123",
+				DefaultText: "Snapshot used as the base for the new snapshot",
+				Required:    true,
+				Destination: &korn.BaseSnapshotName,
+			},
+			&cli.StringSliceFlag{
+				Name:     "set",
+				Usage:    "Replaces the container image of a component in the base snapshot. The image must be referenced by digest. Example: -set controller=quay.io/org/controller@sha256:abc123",
+				Required: true,
+				Validator: func(vals []string) error {
+					for _, v := range vals {
+						if _, _, err := parseOverride(v); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+				Action: func(ctx context.Context, c *cli.Command, vals []string) error {
+					korn.ComponentOverrides = map[string]string{}
+					for _, v := range vals {
+						name, image, _ := parseOverride(v)
+						korn.ComponentOverrides[name] = image
+					}
+					return nil
+				},
+			},
+			&cli.BoolFlag{
+				Name:        "dryrun",
+				Usage:       "Outputs the manifest of the new snapshot without creating it",
+				Value:       false,
+				Destination: &korn.DryRun,
+				DefaultText: strconv.FormatBool(korn.DryRun),
+			},
+			&cli.BoolFlag{
+				Name:        "compare-release",
+				Usage:       "Requires the release label of each component image to match the one in the bundle when validating the new snapshot",
+				Value:       false,
+				DefaultText: strconv.FormatBool(korn.CompareReleaseLabel),
+				Destination: &korn.CompareReleaseLabel,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "Ouptuts the manifest in yaml or json format. Example: -output yaml",
+				DefaultText: korn.OutputType,
+				Validator: func(val string) error {
+					if val != "json" && val != "yaml" {
+						return fmt.Errorf("invalid output type %s: only 'json' or 'yaml' are supported", val)
+					}
+					return nil
+				},
+				Destination: &korn.OutputType,
+			},
+		},
+		Description: "Creates a snapshot from an existing one, replacing the container images of some of its components. The new snapshot is labeled as a korn override and validated as a release candidate before it is created",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			m, err := korn.GenerateOverrideSnapshot()
+			if err != nil {
+				return err
+			}
+			if len(korn.OutputType) > 0 {
+				s := mjson.NewSerializerWithOptions(
+					mjson.DefaultMetaFactory, nil, nil,
+					mjson.SerializerOptions{Yaml: korn.OutputType == "yaml", Pretty: true, Strict: true},
+				)
+				return s.Encode(m, os.Stdout)
+			}
+			s, err := korn.CreateSnapshot(*m)
+			if err != nil {
+				return err
+			}
+			if korn.DryRun {
+				logrus.Infof("Snapshot %s validated, not created in dry run mode", s.Name)
+				return nil
+			}
+			logrus.Infof("Snapshot created %s", s.Name)
+			return nil
+		},
+	}
+}
+
+// parseOverride splits a component image override in the form component=image
+func parseOverride(val string) (string, string, error) {
+	name, image, ok := strings.Cut(val, "=")
+	if !ok || len(name) == 0 || len(image) == 0 {
+		return "", "", fmt.Errorf("invalid component override %q: expected format is component=image", val)
+	}
+	return name, image, nil
+}
@@ -0,0 +1,82 @@
+package snapshot_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jordigilh/korn/cmd/create/snapshot"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	bundleOverride     = "registry.test.com/bundle@sha256:" + strings.Repeat("a", 64)
+	controllerOverride = "registry.test.com/controller@sha256:" + strings.Repeat("b", 64)
+)
+
+var _ = Describe("Create Snapshot Command", func() {
+	var (
+		createTestSetup *testutils.CreateTestSetup
+		cmd             *cli.Command
+	)
+
+	BeforeEach(func() {
+		var err error
+		createTestSetup, err = testutils.NewCreateTestSetup(createFakeScheme())
+		Expect(err).ToNot(HaveOccurred())
+		createTestSetup.WithObjects(testutils.GetCompleteCreateReleaseTestSet()...)
+		createTestSetup.FakeClientBuilder = createTestSetup.FakeClientBuilder.WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", testutils.FilterBySnapshotName)
+
+		cmd = snapshot.CreateCommand()
+	})
+
+	AfterEach(func() {
+		createTestSetup.Cleanup()
+	})
+
+	listOverrides := func(ctx context.Context) []applicationapiv1alpha1.Snapshot {
+		list := applicationapiv1alpha1.SnapshotList{}
+		kubeClient := ctx.Value(internal.KubeCliCtxType).(client.Client)
+		Expect(kubeClient.List(context.TODO(), &list, client.MatchingLabels{konflux.OverrideLabel: "true"})).To(Succeed())
+		return list.Items
+	}
+
+	It("should create the override snapshot", func() {
+		ctx := createTestSetup.WithKubeClientAndMocks()
+
+		err := cmd.Run(ctx, []string{"snapshot", "--from", testutils.TestSnapshotName, "--set", testutils.BundleComponentName + "=" + bundleOverride})
+
+		Expect(err).ToNot(HaveOccurred())
+		overrides := listOverrides(ctx)
+		Expect(overrides).To(HaveLen(1))
+		Expect(overrides[0].Annotations).To(HaveKeyWithValue(konflux.OverrideBaseAnnotation, testutils.TestSnapshotName))
+	})
+
+	DescribeTable("should not create the override snapshot",
+		func(args []string) {
+			ctx := createTestSetup.WithKubeClientAndMocks()
+
+			err := cmd.Run(ctx, append([]string{"snapshot"}, args...))
+
+			Expect(listOverrides(ctx)).To(BeEmpty())
+			if len(args) > 0 && args[len(args)-1] == "yaml" {
+				Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("when only the manifest is requested", []string{"--from", testutils.TestSnapshotName, "--set", testutils.BundleComponentName + "=" + bundleOverride, "--dryrun", "-o", "yaml"}),
+		Entry("without a base snapshot", []string{"--set", testutils.BundleComponentName + "=" + bundleOverride}),
+		Entry("without overrides", []string{"--from", testutils.TestSnapshotName}),
+		Entry("with a malformed override", []string{"--from", testutils.TestSnapshotName, "--set", bundleOverride}),
+		Entry("with an image referenced by tag", []string{"--from", testutils.TestSnapshotName, "--set", testutils.BundleComponentName + "=registry.test.com/bundle:latest"}),
+		Entry("when the override is not a valid candidate", []string{"--from", testutils.TestSnapshotName, "--set", testutils.ControllerComponentName + "=" + controllerOverride}),
+		Entry("with an invalid output type", []string{"--from", testutils.TestSnapshotName, "--set", testutils.BundleComponentName + "=" + bundleOverride, "-o", "xml"}),
+	)
+})
@@ -0,0 +1,108 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/urfave/cli/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	mjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	korn = konflux.Korn{}
+)
+
+func CreateCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "stream",
+		Aliases: []string{"streams"},
+		Usage:   "create the application, components and release plans of a new version stream",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "from",
+				Usage:       "Example: -from operator-1-0",
+				DefaultText: "Application of the version stream used as the base for the new one",
+				Required:    true,
+				Destination: &korn.ApplicationName,
+			},
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "Example: -name operator-1-1",
+				DefaultText: "Name of the application of the new version stream",
+				Required:    true,
+				Destination: &korn.StreamName,
+			},
+			&cli.StringFlag{
+				Name:        "branch",
+				Usage:       "Example: -branch release-1.1",
+				DefaultText: "Git branch the components of the new version stream are built from",
+				Required:    true,
+				Destination: &korn.Branch,
+			},
+			&cli.StringFlag{
+				Name:        "version",
+				Usage:       "Example: -version 1.1",
+				DefaultText: "Version of the new stream, required when the base application is labeled with korn.redhat.io/stream",
+				Destination: &korn.Version,
+			},
+			&cli.BoolFlag{
+				Name:        "dryrun",
+				Usage:       "Validates the new resources with the cluster without creating them",
+				Value:       false,
+				Destination: &korn.DryRun,
+				DefaultText: strconv.FormatBool(korn.DryRun),
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "Outputs the manifests in yaml or json format without creating them. Example: -output yaml",
+				DefaultText: korn.OutputType,
+				Validator: func(val string) error {
+					if val != "json" && val != "yaml" {
+						return fmt.Errorf("invalid output type %s: only 'json' or 'yaml' are supported", val)
+					}
+					return nil
+				},
+				Destination: &korn.OutputType,
+			},
+		},
+		Description: "Creates a new version stream from an existing application: a copy of the application, its components built from the new git branch and its release plans, preserving the korn labels. The ReleasePlanAdmissions in the managed namespaces must be updated separately to admit the new application",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			m, err := korn.GenerateStreamManifests()
+			if err != nil {
+				return err
+			}
+			if len(korn.OutputType) > 0 {
+				list := &corev1.List{TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: "v1"}, Items: []runtime.RawExtension{}}
+				for _, obj := range m.Objects() {
+					list.Items = append(list.Items, runtime.RawExtension{Object: obj})
+				}
+				s := mjson.NewSerializerWithOptions(
+					mjson.DefaultMetaFactory, nil, nil,
+					mjson.SerializerOptions{Yaml: korn.OutputType == "yaml", Pretty: true, Strict: true},
+				)
+				return s.Encode(list, os.Stdout)
+			}
+			if err := korn.CreateStream(*m); err != nil {
+				return err
+			}
+			logrus.Infof("Stream created: application %s with %d components and %d release plans", m.Application.Name, len(m.Components), len(m.ReleasePlans))
+			return nil
+		},
+	}
+}
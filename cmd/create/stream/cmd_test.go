@@ -0,0 +1,71 @@
+package stream_test
+
+import (
+	"context"
+
+	"github.com/jordigilh/korn/cmd/create/stream"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const newStreamName = "test-app-1-1"
+
+var _ = Describe("Create Stream Command", func() {
+	var (
+		createTestSetup *testutils.CreateTestSetup
+		cmd             *cli.Command
+	)
+
+	BeforeEach(func() {
+		var err error
+		createTestSetup, err = testutils.NewCreateTestSetup(createFakeScheme())
+		Expect(err).ToNot(HaveOccurred())
+		createTestSetup.WithObjects(testutils.GetCompleteCreateReleaseTestSet()...)
+
+		cmd = stream.CreateCommand()
+	})
+
+	AfterEach(func() {
+		createTestSetup.Cleanup()
+	})
+
+	getApplication := func(ctx context.Context) error {
+		kubeClient := ctx.Value(internal.KubeCliCtxType).(client.Client)
+		return kubeClient.Get(context.TODO(), client.ObjectKey{Namespace: testutils.TestNamespace, Name: newStreamName}, &applicationapiv1alpha1.Application{})
+	}
+
+	It("should create the new stream", func() {
+		ctx := createTestSetup.WithKubeClientAndMocks()
+
+		err := cmd.Run(ctx, []string{"stream", "--from", testutils.TestAppName, "--name", newStreamName, "--branch", "release-1.1"})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getApplication(ctx)).To(Succeed())
+	})
+
+	DescribeTable("should not create the new stream",
+		func(args []string) {
+			ctx := createTestSetup.WithKubeClientAndMocks()
+
+			err := cmd.Run(ctx, append([]string{"stream"}, args...))
+
+			Expect(getApplication(ctx)).ToNot(Succeed())
+			if len(args) > 0 && args[len(args)-1] == "yaml" {
+				Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("when only the manifests are requested", []string{"--from", testutils.TestAppName, "--name", newStreamName, "--branch", "release-1.1", "--dryrun", "-o", "yaml"}),
+		Entry("without a base application", []string{"--name", newStreamName, "--branch", "release-1.1"}),
+		Entry("without a name", []string{"--from", testutils.TestAppName, "--branch", "release-1.1"}),
+		Entry("without a branch", []string{"--from", testutils.TestAppName, "--name", newStreamName}),
+		Entry("when the base application doesn't exist", []string{"--from", "missing-app", "--name", newStreamName, "--branch", "release-1.1"}),
+		Entry("with an invalid output type", []string{"--from", testutils.TestAppName, "--name", newStreamName, "--branch", "release-1.1", "-o", "xml"}),
+	)
+})
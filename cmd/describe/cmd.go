@@ -0,0 +1,16 @@
+package describe
+
+import (
+	"github.com/jordigilh/korn/cmd/describe/snapshot"
+	"github.com/urfave/cli/v3"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "describe",
+		Usage: "describe snapshot",
+		Commands: []*cli.Command{
+			snapshot.DescribeCommand(),
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	componentsTable = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Image", Type: "string"},
+			{Name: "Git URL", Type: "string"},
+			{Name: "Revision", Type: "string"},
+		},
+	}
+	scenariosTable = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Scenario", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "PipelineRun", Type: "string"},
+			{Name: "Started", Type: "string"},
+			{Name: "Duration", Type: "string"},
+			{Name: "Details", Type: "string"},
+		},
+	}
+	p    = printers.NewTablePrinter(printers.PrintOptions{})
+	korn = konflux.Korn{}
+)
+
+func DescribeCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "snapshot",
+		Aliases: []string{"snapshots"},
+		Usage:   "describe snapshot <snapshot_name>",
+		Arguments: []cli.Argument{&cli.StringArg{
+			Name:        "snapshot",
+			Destination: &korn.SnapshotName,
+		}},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			return ctx, nil
+		},
+		Description: "Shows the details of a snapshot: its test status, approval and korn verdict, the image and git revision of each component, and the results of each integration test scenario run against it",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if korn.SnapshotName == "" {
+				return fmt.Errorf("snapshot name is required")
+			}
+			s, err := korn.GetSnapshot()
+			if err != nil {
+				return err
+			}
+			scenarios, err := konflux.GetIntegrationTestStatuses(*s)
+			if err != nil {
+				return err
+			}
+			describe(os.Stdout, *s, scenarios)
+			return nil
+		},
+	}
+}
+
+func describe(out io.Writer, s applicationapiv1alpha1.Snapshot, scenarios []konflux.IntegrationTestScenarioStatus) {
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintf(w, "Name:\t%s\n", s.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", s.Namespace)
+	fmt.Fprintf(w, "Application:\t%s\n", s.Spec.Application)
+	fmt.Fprintf(w, "Created:\t%s (%s ago)\n", s.CreationTimestamp.Format(time.RFC3339), duration.HumanDuration(time.Since(s.CreationTimestamp.Time)))
+	fmt.Fprintf(w, "Event Type:\t%s\n", s.Labels["pac.test.appstudio.openshift.io/event-type"])
+	fmt.Fprintf(w, "Commit:\t%s\n", strings.TrimSpace(s.Labels["pac.test.appstudio.openshift.io/sha"]+" "+s.Annotations["pac.test.appstudio.openshift.io/sha-title"]))
+	fmt.Fprintf(w, "Branch:\t%s\n", konflux.GetSnapshotBranch(s))
+	fmt.Fprintf(w, "Test Status:\t%s\n", konflux.SnapshotTestStatusDetails(s))
+	fmt.Fprintf(w, "Approval:\t%s\n", konflux.SnapshotApprovalStatusDetails(s))
+	fmt.Fprintf(w, "Korn Verdict:\t%s\n", konflux.SnapshotVerdictStatusDetails(s))
+	w.Flush()
+
+	rows := []metav1.TableRow{}
+	for _, c := range s.Spec.Components {
+		var url, revision string
+		if c.Source.GitSource != nil {
+			url, revision = c.Source.GitSource.URL, c.Source.GitSource.Revision
+		}
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{c.Name, c.ContainerImage, url, revision}})
+	}
+	componentsTable.Rows = rows
+	fmt.Fprintln(out, "\nComponents:")
+	p.PrintObj(componentsTable, out)
+
+	fmt.Fprintln(out, "\nIntegration Tests:")
+	if len(scenarios) == 0 {
+		fmt.Fprintln(out, "No integration test results reported yet")
+		return
+	}
+	rows = []metav1.TableRow{}
+	for _, sc := range scenarios {
+		var started, took string
+		if sc.StartTime != nil {
+			started = sc.StartTime.Format(time.RFC3339)
+			if sc.CompletionTime != nil {
+				took = duration.HumanDuration(sc.CompletionTime.Sub(sc.StartTime.Time))
+			}
+		}
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{sc.ScenarioName, sc.Status, sc.TestPipelineRunName, started, took, sc.Details}})
+	}
+	scenariosTable.Rows = rows
+	p.PrintObj(scenariosTable, out)
+}
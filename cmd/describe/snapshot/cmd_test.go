@@ -0,0 +1,49 @@
+package snapshot_test
+
+import (
+	"github.com/jordigilh/korn/cmd/describe/snapshot"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+)
+
+var _ = Describe("Describe Snapshot Command", func() {
+	var (
+		setup *testutils.TestSetup
+		cmd   *cli.Command
+	)
+
+	BeforeEach(func() {
+		setup = testutils.NewTestSetup(createFakeScheme())
+		setup.FakeClientBuilder = setup.FakeClientBuilder.WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", testutils.FilterBySnapshotName)
+		cmd = snapshot.DescribeCommand()
+	})
+
+	It("should describe the snapshot with its integration test results", func() {
+		s := testutils.NewTestSnapshot()
+		s.Annotations = map[string]string{konflux.IntegrationTestStatusAnnotation: `[{"scenario":"e2e","status":"TestPassed","testPipelineRunName":"e2e-abc12","startTime":"2025-01-01T10:00:00Z","completionTime":"2025-01-01T10:05:00Z"}]`}
+		ctx := setup.WithObjects(s).WithKubeClient()
+
+		err := cmd.Run(ctx, []string{"snapshot", testutils.TestSnapshotName})
+
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable("should fail to describe",
+		func(annotations map[string]string, args []string) {
+			s := testutils.NewTestSnapshot()
+			s.Annotations = annotations
+			ctx := setup.WithObjects(s).WithKubeClient()
+
+			err := cmd.Run(ctx, args)
+
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("without a snapshot name", nil, []string{"snapshot"}),
+		Entry("a snapshot that does not exist", nil, []string{"snapshot", "missing-snapshot"}),
+		Entry("a snapshot with an invalid integration test status", map[string]string{konflux.IntegrationTestStatusAnnotation: "{"}, []string{"snapshot", testutils.TestSnapshotName}),
+	)
+})
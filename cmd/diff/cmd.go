@@ -0,0 +1,16 @@
+package diff
+
+import (
+	"github.com/jordigilh/korn/cmd/diff/snapshot"
+	"github.com/urfave/cli/v3"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "diff",
+		Usage: "diff <resources>",
+		Commands: []*cli.Command{
+			snapshot.DiffCommand(),
+		},
+	}
+}
@@ -0,0 +1,222 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	table = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Component", Type: "string"},
+			{Name: "Change", Type: "string"},
+			{Name: "From Image", Type: "string"},
+			{Name: "To Image", Type: "string"},
+			{Name: "From Revision", Type: "string"},
+			{Name: "To Revision", Type: "string"},
+			{Name: "Commit", Type: "string"},
+		},
+	}
+	labelsTable = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Component", Type: "string"},
+			{Name: "Label", Type: "string"},
+			{Name: "From", Type: "string"},
+			{Name: "To", Type: "string"},
+		},
+	}
+	p          = printers.NewTablePrinter(printers.PrintOptions{})
+	korn       = konflux.Korn{}
+	outputType = "table"
+)
+
+func DiffCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "snapshot",
+		Aliases: []string{"snapshots"},
+		Usage:   "compare snapshots",
+		Arguments: []cli.Argument{
+			&cli.StringArg{
+				Name:        "from",
+				Destination: &korn.BaseSnapshotName,
+			},
+			&cli.StringArg{
+				Name:        "to",
+				Destination: &korn.SnapshotName,
+			},
+		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			korn.PodClient = ctx.Value(internal.PodmanCliCtxType).(internal.ImageClient)
+			korn.GitClient = ctx.Value(internal.GitCliCtxType).(internal.GitCommitVersioner)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "application",
+				Aliases:     []string{"app"},
+				Usage:       "Example: -application my-application",
+				DefaultText: "Application whose last release is used with -against-last-release",
+				Destination: &korn.ApplicationName,
+			},
+			&cli.BoolFlag{
+				Name:        "against-last-release",
+				Usage:       "Example: -against-last-release",
+				DefaultText: "Compares the snapshot with the one used in the last successful release. Without a snapshot, the current candidate for release is compared",
+				Destination: &korn.AgainstLastRelease,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "Example: -output markdown",
+				DefaultText: "Output format: table, markdown or json",
+				Value:       "table",
+				Validator: func(val string) error {
+					if val != "table" && val != "markdown" && val != "json" {
+						return fmt.Errorf("invalid output type %s: only 'table', 'markdown' or 'json' are supported", val)
+					}
+					return nil
+				},
+				Destination: &outputType,
+			},
+		},
+		Description: "Compares the components of two snapshots, listing the components added or removed, the changes in their image digests and git revisions, and the image labels that differ",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if korn.AgainstLastRelease {
+				if len(korn.SnapshotName) > 0 {
+					return errors.New("only one snapshot can be compared against the last release")
+				}
+				// The only snapshot given is compared against the one in the last release
+				korn.SnapshotName, korn.BaseSnapshotName = korn.BaseSnapshotName, ""
+			}
+			d, err := korn.DiffSnapshot()
+			if err != nil {
+				return err
+			}
+			switch outputType {
+			case "json":
+				b, err := json.MarshalIndent(d, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+			case "markdown":
+				printMarkdown(os.Stdout, *d)
+			default:
+				print(*d)
+			}
+			return nil
+		},
+	}
+}
+
+func print(d konflux.SnapshotDiff) {
+	rows := []metav1.TableRow{}
+	labelRows := []metav1.TableRow{}
+	for _, c := range d.Components {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{
+			c.Name,
+			string(c.Change),
+			shortDigest(c.FromImage),
+			shortDigest(c.ToImage),
+			shortRevision(c.FromRevision),
+			shortRevision(c.ToRevision),
+			commitTitle(c),
+		}})
+		for _, l := range c.Labels {
+			labelRows = append(labelRows, metav1.TableRow{Cells: []interface{}{c.Name, l.Name, l.From, l.To}})
+		}
+	}
+	table.Rows = rows
+	p.PrintObj(table, os.Stdout)
+	if len(labelRows) > 0 {
+		labelsTable.Rows = labelRows
+		fmt.Println()
+		p.PrintObj(labelsTable, os.Stdout)
+	}
+}
+
+func printMarkdown(w io.Writer, d konflux.SnapshotDiff) {
+	fmt.Fprintf(w, "## Snapshot diff: %s → %s\n\n", d.From, d.To)
+	if len(d.Components) == 0 {
+		fmt.Fprintln(w, "No differences found.")
+		return
+	}
+	fmt.Fprintln(w, "| Component | Change | Image | Revision | Commit |")
+	fmt.Fprintln(w, "|-----------|--------|-------|----------|--------|")
+	hasLabels := false
+	for _, c := range d.Components {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n", c.Name, c.Change,
+			markdownChange(shortDigest(c.FromImage), shortDigest(c.ToImage)),
+			markdownChange(shortRevision(c.FromRevision), shortRevision(c.ToRevision)),
+			markdownEscape(commitTitle(c)))
+		hasLabels = hasLabels || len(c.Labels) > 0
+	}
+	if !hasLabels {
+		return
+	}
+	fmt.Fprint(w, "\n### Image labels\n\n")
+	fmt.Fprintln(w, "| Component | Label | From | To |")
+	fmt.Fprintln(w, "|-----------|-------|------|----|")
+	for _, c := range d.Components {
+		for _, l := range c.Labels {
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n", c.Name, l.Name, markdownEscape(l.From), markdownEscape(l.To))
+		}
+	}
+}
+
+// markdownChange formats the values of a field before and after the change
+func markdownChange(from, to string) string {
+	switch {
+	case len(from) == 0 && len(to) == 0:
+		return ""
+	case from == to:
+		return fmt.Sprintf("`%s`", from)
+	case len(from) == 0:
+		return fmt.Sprintf("`%s`", to)
+	case len(to) == 0:
+		return fmt.Sprintf("`%s`", from)
+	}
+	return fmt.Sprintf("`%s` → `%s`", from, to)
+}
+
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// commitTitle returns the title of the commit the component is built from in the newest snapshot, or in the oldest
+// one if the component was removed
+func commitTitle(c konflux.ComponentDiff) string {
+	if c.Change == konflux.ComponentRemoved {
+		return c.FromCommitTitle
+	}
+	return c.ToCommitTitle
+}
+
+// shortDigest returns the first 12 characters of the image digest, or the image itself when it is not referenced by
+// digest
+func shortDigest(image string) string {
+	d := internal.ImageDigest(image)
+	_, hex, ok := strings.Cut(d, ":")
+	if !ok {
+		return image
+	}
+	return hex[:min(len(hex), 12)]
+}
+
+func shortRevision(rev string) string {
+	return rev[:min(len(rev), 7)]
+}
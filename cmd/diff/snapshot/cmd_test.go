@@ -0,0 +1,64 @@
+package snapshot_test
+
+import (
+	"github.com/jordigilh/korn/cmd/diff/snapshot"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+)
+
+const otherSnapshotName = "other-snapshot"
+
+var _ = Describe("Diff Snapshot Command", func() {
+	var (
+		createTestSetup *testutils.CreateTestSetup
+		cmd             *cli.Command
+	)
+
+	BeforeEach(func() {
+		var err error
+		createTestSetup, err = testutils.NewCreateTestSetup(createFakeScheme())
+		Expect(err).ToNot(HaveOccurred())
+		other := testutils.NewSnapshot(otherSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName, "def456abc123")
+		other.Spec.Components[0].ContainerImage = "registry.test.com/controller@sha256:fff999"
+		createTestSetup.WithObjects(append(testutils.GetCompleteCreateReleaseTestSet(), other,
+			testutils.NewSuccessfulRelease(testutils.TestReleaseName, testutils.TestNamespace, testutils.TestSnapshotName, testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName))...)
+		createTestSetup.FakeClientBuilder = createTestSetup.FakeClientBuilder.WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", testutils.FilterBySnapshotName)
+
+		cmd = snapshot.DiffCommand()
+	})
+
+	AfterEach(func() {
+		createTestSetup.Cleanup()
+	})
+
+	DescribeTable("should compare snapshots",
+		func(args []string) {
+			ctx := createTestSetup.WithKubeClientAndMocks()
+
+			err := cmd.Run(ctx, append([]string{"snapshot"}, args...))
+
+			Expect(err).ToNot(HaveOccurred())
+		},
+		Entry("in table format", []string{testutils.TestSnapshotName, otherSnapshotName}),
+		Entry("in markdown format", []string{testutils.TestSnapshotName, otherSnapshotName, "-o", "markdown"}),
+		Entry("in json format", []string{testutils.TestSnapshotName, otherSnapshotName, "-o", "json"}),
+		Entry("against the last release", []string{"--against-last-release", otherSnapshotName}),
+	)
+
+	DescribeTable("should fail to compare snapshots",
+		func(args []string) {
+			ctx := createTestSetup.WithKubeClientAndMocks()
+
+			err := cmd.Run(ctx, append([]string{"snapshot"}, args...))
+
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("with a single snapshot", []string{testutils.TestSnapshotName}),
+		Entry("with a snapshot that does not exist", []string{testutils.TestSnapshotName, "missing-snapshot"}),
+		Entry("with two snapshots against the last release", []string{"--against-last-release", testutils.TestSnapshotName, otherSnapshotName}),
+		Entry("with an invalid output type", []string{testutils.TestSnapshotName, otherSnapshotName, "-o", "yaml"}),
+	)
+})
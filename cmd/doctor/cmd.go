@@ -0,0 +1,97 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	table = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Check", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+	}
+	p    = printers.NewTablePrinter(printers.PrintOptions{})
+	korn = konflux.Korn{}
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "check the korn onboarding of an application",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			korn.PodClient = ctx.Value(internal.PodmanCliCtxType).(internal.ImageClient)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "application",
+				Aliases:     []string{"app"},
+				Usage:       "Example: -application my-application",
+				DefaultText: "Application to check",
+				Destination: &korn.ApplicationName,
+			},
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
+		},
+		Description: "Checks that the application, its components and release plans are labeled as korn expects and that the bundle image of the latest snapshot carries the label referenced by each component. Prints how to fix each problem found and fails when any check fails",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
+			if korn.ApplicationName == "" {
+				return fmt.Errorf("application name is required")
+			}
+			checks, err := korn.Diagnose()
+			if err != nil {
+				return err
+			}
+			return print(os.Stdout, checks)
+		},
+	}
+}
+
+// print writes the result of each check followed by the fixes of the ones that did not pass, and returns an error
+// when any check failed
+func print(out io.Writer, checks []konflux.Check) error {
+	rows := []metav1.TableRow{}
+	fixes := []string{}
+	failures := 0
+	for _, c := range checks {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{c.Name, c.Status, c.Message}})
+		if c.Status == konflux.CheckFailed {
+			failures++
+		}
+		if len(c.Fix) > 0 {
+			fixes = append(fixes, c.Fix)
+		}
+	}
+	table.Rows = rows
+	if err := p.PrintObj(table, out); err != nil {
+		return err
+	}
+	if len(fixes) > 0 {
+		fmt.Fprintln(out, "\nSuggested fixes:")
+		for _, f := range fixes {
+			fmt.Fprintf(out, "  %s\n", f)
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d checks failed", failures, len(checks))
+	}
+	return nil
+}
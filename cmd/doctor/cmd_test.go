@@ -0,0 +1,54 @@
+package doctor_test
+
+import (
+	"github.com/jordigilh/korn/cmd/doctor"
+	"github.com/jordigilh/korn/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Doctor Command", func() {
+	var (
+		createTestSetup *testutils.CreateTestSetup
+		cmd             *cli.Command
+	)
+
+	BeforeEach(func() {
+		var err error
+		createTestSetup, err = testutils.NewCreateTestSetup(createFakeScheme())
+		Expect(err).ToNot(HaveOccurred())
+		cmd = doctor.Command()
+	})
+
+	AfterEach(func() {
+		createTestSetup.Cleanup()
+	})
+
+	It("should pass all checks of an onboarded application", func() {
+		createTestSetup.WithObjects(testutils.GetCompleteCreateReleaseTestSet()...)
+		ctx := createTestSetup.WithKubeClientAndMocks()
+
+		err := cmd.Run(ctx, []string{"doctor", "--app", testutils.TestAppName})
+
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable("should fail",
+		func(objs []runtime.Object, args []string, expected string) {
+			createTestSetup.WithObjects(objs...)
+			ctx := createTestSetup.WithKubeClientAndMocks()
+
+			err := cmd.Run(ctx, append([]string{"doctor"}, args...))
+
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+		Entry("without an application", nil, []string{}, "application name is required"),
+		Entry("when the application doesn't exist", nil, []string{"--app", testutils.TestAppName}, "application test-app not found"),
+		Entry("when the application is not onboarded", []runtime.Object{
+			testutils.NewApplication(testutils.TestAppName, testutils.TestNamespace, nil),
+		}, []string{"--app", testutils.TestAppName}, "2 of 2 checks failed"),
+		Entry("when the operator and the application are set", nil, []string{"--app", testutils.TestAppName, "--operator", "my-operator"}, "mutually exclusive"),
+	)
+})
@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+)
+
+// OperatorFlag returns the flag that addresses the application by the version stream of an operator instead of by its name
+func OperatorFlag(k *konflux.Korn) cli.Flag {
+	return &cli.StringFlag{
+		Name:        "operator",
+		Usage:       "Example: -operator my-operator -version 1.1",
+		DefaultText: "Resolves the application whose korn.redhat.io/stream label matches the operator and whose korn.redhat.io/version label matches the major and minor of the version. Defaults to the latest version when no version is provided",
+		Destination: &k.Operator,
+	}
+}
+
+// VersionFlag returns the flag with the version of the stream resolved with the operator flag
+func VersionFlag(k *konflux.Korn) cli.Flag {
+	return &cli.StringFlag{
+		Name:        "version",
+		Usage:       "Example: -operator my-operator -version 1.1",
+		DefaultText: "Version stream of the operator. A full version, such as 1.1.3, also restricts the snapshots to the ones whose bundle has that version",
+		Destination: &k.Version,
+	}
+}
+
+// WorkersFlag returns the flag with the number of snapshots validated concurrently when looking for a release candidate
+func WorkersFlag(k *konflux.Korn) cli.Flag {
+	return &cli.IntFlag{
+		Name:        "workers",
+		Usage:       "Example: -workers 8",
+		DefaultText: "Maximum number of snapshots validated concurrently when looking for a candidate",
+		Value:       konflux.DefaultWorkers,
+		Destination: &k.Workers,
+	}
+}
+
+// ResolveStream sets the application to the one of the operator's version stream when the operator is provided. A
+// version with only the major and minor, such as 1.1, is consumed by the resolution so that it does not filter the
+// snapshots.
+func ResolveStream(k *konflux.Korn) error {
+	if len(k.Operator) == 0 {
+		return nil
+	}
+	if len(k.ApplicationName) > 0 {
+		return errors.New("the application and operator flags are mutually exclusive")
+	}
+	app, err := k.GetApplicationForStream()
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Resolved application %s for operator %s with version %s", app.Name, k.Operator, k.Version)
+	k.ApplicationName = app.Name
+	if strings.Count(strings.TrimPrefix(k.Version, "v"), ".") < 2 {
+		k.Version = ""
+	}
+	return nil
+}
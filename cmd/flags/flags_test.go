@@ -0,0 +1,57 @@
+package flags_test
+
+import (
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Resolving the application of a version stream", func() {
+	var korn konflux.Korn
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(applicationapiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(releaseapiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		korn = konflux.Korn{
+			Namespace: testutils.TestNamespace,
+			KubeClient: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+				testutils.NewStreamApplication("operator-1-0", testutils.TestNamespace, "my-operator", "1.0"),
+				testutils.NewStreamApplication("operator-1-1", testutils.TestNamespace, "my-operator", "1.1"),
+			).Build(),
+		}
+	})
+
+	DescribeTable("should set the application",
+		func(version, expectedApp, expectedVersion string) {
+			korn.Operator, korn.Version = "my-operator", version
+
+			Expect(flags.ResolveStream(&korn)).To(Succeed())
+			Expect(korn.ApplicationName).To(Equal(expectedApp))
+			Expect(korn.Version).To(Equal(expectedVersion))
+		},
+		Entry("consuming a major and minor version", "1.0", "operator-1-0", ""),
+		Entry("keeping a full version to filter the snapshots", "v1.0.2", "operator-1-0", "v1.0.2"),
+		Entry("of the latest version", "", "operator-1-1", ""),
+	)
+
+	It("should keep the application when no operator is provided", func() {
+		korn.ApplicationName, korn.Version = "operator-1-0", "1.1"
+
+		Expect(flags.ResolveStream(&korn)).To(Succeed())
+		Expect(korn.ApplicationName).To(Equal("operator-1-0"))
+		Expect(korn.Version).To(Equal("1.1"))
+	})
+
+	It("should fail when both the application and the operator are provided", func() {
+		korn.ApplicationName, korn.Operator = "operator-1-0", "my-operator"
+
+		Expect(flags.ResolveStream(&korn)).To(MatchError("the application and operator flags are mutually exclusive"))
+	})
+})
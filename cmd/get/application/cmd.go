@@ -5,6 +5,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/jordigilh/korn/cmd/get/filter"
+	"github.com/jordigilh/korn/cmd/get/output"
 	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
@@ -12,7 +14,6 @@ import (
 	"github.com/urfave/cli/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
-	"k8s.io/cli-runtime/pkg/printers"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -21,11 +22,15 @@ var (
 		ColumnDefinitions: []metav1.TableColumnDefinition{
 			{Name: "Name", Type: "string"},
 			{Name: "Type", Type: "string"},
+			{Name: "Stream", Type: "string"},
+			{Name: "Version", Type: "string"},
 			{Name: "Age", Type: "string"},
+			{Name: "Display Name", Type: "string", Priority: 1},
 		},
 	}
-	p    = printers.NewTablePrinter(printers.PrintOptions{})
-	korn = konflux.Korn{}
+	korn    = konflux.Korn{}
+	filters = filter.Options{}
+	outputs = output.Options{}
 )
 
 func GetCommand() *cli.Command {
@@ -38,6 +43,7 @@ func GetCommand() *cli.Command {
 		Arguments: []cli.Argument{&cli.StringArg{
 			Destination: &korn.ApplicationName,
 		}},
+		Flags: append(filters.Flags(), outputs.Flag()),
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
 			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
@@ -49,21 +55,19 @@ func GetCommand() *cli.Command {
 				if err != nil {
 					return err
 				}
-				print(l.Items)
-				return nil
+				return print(l.Items, false)
 			}
 			a, err := korn.GetApplication()
 			if err != nil {
 				return err
 			}
-			print([]applicationapiv1alpha1.Application{*a})
-			return nil
+			return print([]applicationapiv1alpha1.Application{*a}, true)
 		},
 	}
 }
 
-func print(apps []applicationapiv1alpha1.Application) {
-	rows := []metav1.TableRow{}
+func print(apps []applicationapiv1alpha1.Application, single bool) error {
+	rows := []filter.Row{}
 	for _, v := range apps {
 		if v.CreationTimestamp.IsZero() {
 			continue
@@ -72,12 +76,23 @@ func print(apps []applicationapiv1alpha1.Application) {
 			logrus.Debugf("Application %s has no labels", v.Name)
 			continue
 		}
-		rows = append(rows, metav1.TableRow{Cells: []interface{}{
-			v.Name,
-			v.Labels[konflux.ApplicationTypeLabel],
-			duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
-		}})
+		rows = append(rows, filter.Row{
+			TableRow: metav1.TableRow{Cells: []interface{}{
+				v.Name,
+				v.Labels[konflux.ApplicationTypeLabel],
+				v.Labels[konflux.StreamLabel],
+				v.Labels[konflux.StreamVersionLabel],
+				duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
+				v.Spec.DisplayName,
+			}},
+			Object: &v,
+			Status: filter.LatestConditionReason(v.Status.Conditions),
+		})
 	}
-	table.Rows = rows
-	p.PrintObj(table, os.Stdout)
+	tableRows, err := filters.Apply(rows)
+	if err != nil {
+		return err
+	}
+	table.Rows = tableRows
+	return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, single)
 }
@@ -3,8 +3,10 @@ package get
 import (
 	"github.com/jordigilh/korn/cmd/get/application"
 	"github.com/jordigilh/korn/cmd/get/component"
+	"github.com/jordigilh/korn/cmd/get/environment"
 	"github.com/jordigilh/korn/cmd/get/release"
 	"github.com/jordigilh/korn/cmd/get/releaseplan"
+	"github.com/jordigilh/korn/cmd/get/releaseplanadmission"
 	"github.com/jordigilh/korn/cmd/get/snapshot"
 	"github.com/urfave/cli/v3"
 )
@@ -19,6 +21,8 @@ func Command() *cli.Command {
 			snapshot.GetCommand(),
 			release.GetCommand(),
 			releaseplan.GetCommand(),
+			releaseplanadmission.GetCommand(),
+			environment.GetCommand(),
 		},
 	}
 }
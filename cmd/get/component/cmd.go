@@ -5,6 +5,9 @@ import (
 	"os"
 	"time"
 
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/cmd/get/filter"
+	"github.com/jordigilh/korn/cmd/get/output"
 	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
@@ -12,7 +15,6 @@ import (
 	"github.com/urfave/cli/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
-	"k8s.io/cli-runtime/pkg/printers"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,10 +25,13 @@ var (
 			{Name: "Type", Type: "string"},
 			{Name: "Bundle Label", Type: "string"},
 			{Name: "Age", Type: "string"},
+			{Name: "Git URL", Type: "string", Priority: 1},
+			{Name: "Image", Type: "string", Priority: 1},
 		},
 	}
-	p    = printers.NewTablePrinter(printers.PrintOptions{})
-	korn = konflux.Korn{}
+	korn    = konflux.Korn{}
+	filters = filter.Options{}
+	outputs = output.Options{}
 )
 
 func GetCommand() *cli.Command {
@@ -43,36 +48,41 @@ func GetCommand() *cli.Command {
 			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
 			return ctx, nil
 		},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:        "application",
 				Aliases:     []string{"app"},
 				Usage:       "Example: -application my-application",
 				DefaultText: "Application where the components are derived from",
 				Destination: &korn.ApplicationName,
-			}},
+			},
+			outputs.Flag(),
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
+		}, filters.Flags()...),
 		Description: "Retrieves a component or the list of components. If application is not provided, it will list all components in the namespace",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
 			if len(korn.ComponentName) == 0 {
 				l, err := korn.ListComponents()
 				if err != nil {
 					return err
 				}
-				print(l)
-				return nil
+				return print(l, false)
 			}
 			a, err := korn.GetComponent()
 			if err != nil {
 				return err
 			}
-			print([]applicationapiv1alpha1.Component{*a})
-			return nil
+			return print([]applicationapiv1alpha1.Component{*a}, true)
 		},
 	}
 }
 
-func print(comps []applicationapiv1alpha1.Component) {
-	rows := []metav1.TableRow{}
+func print(comps []applicationapiv1alpha1.Component, single bool) error {
+	rows := []filter.Row{}
 	for _, v := range comps {
 		if v.CreationTimestamp.IsZero() {
 			continue
@@ -81,13 +91,31 @@ func print(comps []applicationapiv1alpha1.Component) {
 			logrus.Debugf("Component %s has no labels", v.Name)
 			continue
 		}
-		rows = append(rows, metav1.TableRow{Cells: []interface{}{
-			v.Name,
-			v.Labels[konflux.ComponentTypeLabel],
-			v.Labels[konflux.BundleReferenceLabel],
-			duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
-		}})
+		rows = append(rows, filter.Row{
+			TableRow: metav1.TableRow{Cells: []interface{}{
+				v.Name,
+				v.Labels[konflux.ComponentTypeLabel],
+				v.Labels[konflux.BundleReferenceLabel],
+				duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
+				gitURL(v),
+				v.Spec.ContainerImage,
+			}},
+			Object: &v,
+			Status: filter.LatestConditionReason(v.Status.Conditions),
+		})
+	}
+	tableRows, err := filters.Apply(rows)
+	if err != nil {
+		return err
+	}
+	table.Rows = tableRows
+	return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, single)
+}
+
+// gitURL returns the repository URL the component is built from
+func gitURL(c applicationapiv1alpha1.Component) string {
+	if c.Spec.Source.GitSource == nil {
+		return ""
 	}
-	table.Rows = rows
-	p.PrintObj(table, os.Stdout)
+	return c.Spec.Source.GitSource.URL
 }
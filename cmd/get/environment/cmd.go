@@ -0,0 +1,103 @@
+package environment
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/cmd/get/filter"
+	"github.com/jordigilh/korn/cmd/get/output"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	table = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Application", Type: "string"},
+			{Name: "Release Plan", Type: "string"},
+			{Name: "Target", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Age", Type: "string"},
+			{Name: "Release Plan Admission", Type: "string", Priority: 1},
+		},
+	}
+	korn    = konflux.Korn{}
+	filters = filter.Options{}
+	outputs = output.Options{}
+)
+
+func GetCommand() *cli.Command {
+
+	return &cli.Command{
+		Name:    "environment",
+		Aliases: []string{"env", "envs", "environments"},
+		Usage:   "get environments",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			return ctx, nil
+		},
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:        "application",
+				Aliases:     []string{"app"},
+				Usage:       "Example: -application my-application",
+				DefaultText: "Application where the environments are released to",
+				Destination: &korn.ApplicationName,
+			},
+			outputs.Flag(),
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
+		}, filters.Flags()...),
+		Description: "Lists the environments where the applications are released to, as defined by the korn.redhat.io/environment label of their release plans. Formats other than the table print the release plans that define the environments",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
+			l, err := korn.ListEnvironments()
+			if err != nil {
+				return err
+			}
+			return print(l)
+		},
+	}
+}
+
+func print(envs []konflux.Environment) error {
+	rows := []filter.Row{}
+	for _, v := range envs {
+		if v.ReleasePlan.CreationTimestamp.IsZero() {
+			continue
+		}
+		status := "Inactive"
+		if v.ReleasePlan.Status.ReleasePlanAdmission.Active {
+			status = "Active"
+		}
+		rows = append(rows, filter.Row{
+			TableRow: metav1.TableRow{Cells: []interface{}{
+				v.Name,
+				v.ReleasePlan.Spec.Application,
+				v.ReleasePlan.Name,
+				v.ReleasePlan.Spec.Target,
+				status,
+				duration.HumanDuration(time.Since(v.ReleasePlan.CreationTimestamp.Time)),
+				v.ReleasePlan.Status.ReleasePlanAdmission.Name,
+			}},
+			Object: &v.ReleasePlan,
+			Status: status,
+		})
+	}
+	tableRows, err := filters.Apply(rows)
+	if err != nil {
+		return err
+	}
+	table.Rows = tableRows
+	return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, false)
+}
@@ -0,0 +1,49 @@
+package environment_test
+
+import (
+	"github.com/jordigilh/korn/cmd/get/environment"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Get Environment Command", func() {
+	var (
+		testSetup *testutils.TestSetup
+		cmd       *cli.Command
+	)
+
+	BeforeEach(func() {
+		testSetup = testutils.NewTestSetup(createFakeScheme())
+		cmd = environment.GetCommand()
+	})
+
+	releasePlans := func() []runtime.Object {
+		return []runtime.Object{
+			testutils.NewStagingReleasePlan("staging-releaseplan", testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewReleasePlan("dev-releaseplan", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.EnvironmentLabel: "dev"}),
+			testutils.NewReleasePlan("unlabeled-releaseplan", testutils.TestNamespace, testutils.OtherAppName, nil),
+		}
+	}
+
+	DescribeTable("should list the environments",
+		func(args []string, expectError bool) {
+			ctx := testSetup.WithObjects(releasePlans()...).WithKubeClient()
+
+			err := cmd.Run(ctx, append([]string{""}, args...))
+
+			if expectError {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+			}
+		},
+		Entry("in the namespace", []string{}, false),
+		Entry("of an application", []string{"--app", testutils.TestAppName}, false),
+		Entry("with the release plans in yaml", []string{"--app", testutils.TestAppName, "-o", "yaml"}, false),
+		Entry("with an unsupported format", []string{"-o", "xml"}, true),
+	)
+})
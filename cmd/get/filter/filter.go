@@ -0,0 +1,148 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	SortByAge    = "age"
+	SortByName   = "name"
+	SortByStatus = "status"
+)
+
+// Options contains the filters and the sort order shared by the get commands
+type Options struct {
+	// Selector is a label selector, such as "env=staging,tier!=frontend"
+	Selector string
+	// Since restricts the resources to the ones created within this period
+	Since time.Duration
+	// Until restricts the resources to the ones created before this period
+	Until time.Duration
+	// SortBy is the field used to sort the resources: age, name or status. The order of the list is kept when empty.
+	SortBy string
+	// Status restricts the resources to the ones with this status, ignoring the case
+	Status string
+	// Limit is the maximum number of resources returned once filtered and sorted. Zero means no limit.
+	Limit int
+}
+
+// Row is a row printed by a get command together with the resource and the status used to filter and sort it
+type Row struct {
+	metav1.TableRow
+	Object metav1.Object
+	Status string
+}
+
+// Flags returns the flags that set the options
+func (o *Options) Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "selector",
+			Aliases:     []string{"l"},
+			Usage:       "Example: -selector korn.redhat.io/environment=staging",
+			DefaultText: "Label selector to filter on. Supports '=', '==', '!=', 'in', 'notin' and existence",
+			Validator: func(val string) error {
+				_, err := labels.Parse(val)
+				return err
+			},
+			Destination: &o.Selector,
+		},
+		&cli.DurationFlag{
+			Name:        "since",
+			Usage:       "Example: -since 72h",
+			DefaultText: "Only lists the resources created within this period",
+			Destination: &o.Since,
+		},
+		&cli.DurationFlag{
+			Name:        "until",
+			Usage:       "Example: -since 72h -until 24h",
+			DefaultText: "Only lists the resources created before this period",
+			Destination: &o.Until,
+		},
+		&cli.StringFlag{
+			Name:        "sort-by",
+			Usage:       "Example: -sort-by name",
+			DefaultText: "Sorts the resources by age (newest first), name or status",
+			Validator: func(val string) error {
+				if !slices.Contains([]string{SortByAge, SortByName, SortByStatus}, val) {
+					return fmt.Errorf("invalid sort field %s: only 'age', 'name' or 'status' are supported", val)
+				}
+				return nil
+			},
+			Destination: &o.SortBy,
+		},
+		&cli.StringFlag{
+			Name:        "status",
+			Usage:       "Example: -status Failed",
+			DefaultText: "Only lists the resources with this status",
+			Destination: &o.Status,
+		},
+	}
+}
+
+// Apply returns the rows whose resources match the options, sorted by the field requested and restricted to the
+// first Limit rows. The resources are kept in the rows so that they can be printed in other formats than the table
+func (o Options) Apply(rows []Row) ([]metav1.TableRow, error) {
+	selector, err := labels.Parse(o.Selector)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	filtered := []Row{}
+	for _, r := range rows {
+		created := r.Object.GetCreationTimestamp().Time
+		switch {
+		case !selector.Matches(labels.Set(r.Object.GetLabels())):
+		case o.Since > 0 && created.Before(now.Add(-o.Since)):
+		case o.Until > 0 && created.After(now.Add(-o.Until)):
+		case len(o.Status) > 0 && !strings.EqualFold(r.Status, o.Status):
+		default:
+			filtered = append(filtered, r)
+		}
+	}
+	switch o.SortBy {
+	case SortByAge:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Object.GetCreationTimestamp().After(filtered[j].Object.GetCreationTimestamp().Time)
+		})
+	case SortByName:
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Object.GetName() < filtered[j].Object.GetName() })
+	case SortByStatus:
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Status < filtered[j].Status })
+	}
+	if o.Limit > 0 && len(filtered) > o.Limit {
+		filtered = filtered[:o.Limit]
+	}
+	ret := make([]metav1.TableRow, 0, len(filtered))
+	for _, r := range filtered {
+		if obj, ok := r.Object.(runtime.Object); ok {
+			r.TableRow.Object = runtime.RawExtension{Object: obj}
+		}
+		ret = append(ret, r.TableRow)
+	}
+	return ret, nil
+}
+
+// LatestConditionReason returns the reason of the condition that changed last, used as the status of the resources
+// that don't define a more specific one
+func LatestConditionReason(conditions []metav1.Condition) string {
+	var latest *metav1.Condition
+	for i, c := range conditions {
+		if latest == nil || c.LastTransitionTime.After(latest.LastTransitionTime.Time) {
+			latest = &conditions[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Reason
+}
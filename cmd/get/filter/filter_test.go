@@ -0,0 +1,74 @@
+package filter_test
+
+import (
+	"time"
+
+	"github.com/jordigilh/korn/cmd/get/filter"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Filtering and sorting the rows of get commands", func() {
+	newRow := func(name, status string, age time.Duration, labels map[string]string) filter.Row {
+		return filter.Row{
+			TableRow: metav1.TableRow{Cells: []interface{}{name}},
+			Object: &metav1.ObjectMeta{
+				Name:              name,
+				Labels:            labels,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+			Status: status,
+		}
+	}
+
+	rows := func() []filter.Row {
+		return []filter.Row{
+			newRow("bravo", "Succeeded", 2*time.Hour, map[string]string{"env": "staging"}),
+			newRow("charlie", "Failed", time.Hour, map[string]string{"env": "production"}),
+			newRow("alpha", "Progressing", 3*time.Hour, nil),
+		}
+	}
+
+	names := func(tableRows []metav1.TableRow) []string {
+		ret := []string{}
+		for _, r := range tableRows {
+			ret = append(ret, r.Cells[0].(string))
+		}
+		return ret
+	}
+
+	DescribeTable("should apply the options",
+		func(opts filter.Options, expected []string) {
+			tableRows, err := opts.Apply(rows())
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names(tableRows)).To(Equal(expected))
+		},
+		Entry("keeping the order without options", filter.Options{}, []string{"bravo", "charlie", "alpha"}),
+		Entry("by label selector", filter.Options{Selector: "env in (staging,production)"}, []string{"bravo", "charlie"}),
+		Entry("by label absence", filter.Options{Selector: "!env"}, []string{"alpha"}),
+		Entry("by creation period", filter.Options{Since: 150 * time.Minute, Until: 90 * time.Minute}, []string{"bravo"}),
+		Entry("by status ignoring the case", filter.Options{Status: "failed"}, []string{"charlie"}),
+		Entry("sorted by age", filter.Options{SortBy: filter.SortByAge}, []string{"charlie", "bravo", "alpha"}),
+		Entry("sorted by name", filter.Options{SortBy: filter.SortByName}, []string{"alpha", "bravo", "charlie"}),
+		Entry("sorted by status", filter.Options{SortBy: filter.SortByStatus}, []string{"charlie", "alpha", "bravo"}),
+		Entry("limited once filtered and sorted", filter.Options{Selector: "env", SortBy: filter.SortByName, Limit: 1}, []string{"bravo"}),
+	)
+
+	It("should fail with an invalid selector", func() {
+		_, err := filter.Options{Selector: "env in staging"}.Apply(rows())
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should use the reason of the latest condition as status", func() {
+		conditions := []metav1.Condition{
+			{Type: "Created", Reason: "OK", LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+			{Type: "Updated", Reason: "Error", LastTransitionTime: metav1.NewTime(time.Now())},
+		}
+
+		Expect(filter.LatestConditionReason(conditions)).To(Equal("Error"))
+		Expect(filter.LatestConditionReason(nil)).To(BeEmpty())
+	})
+})
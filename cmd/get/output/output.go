@@ -0,0 +1,120 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const Wide = "wide"
+
+// Options contains the output format shared by the get commands
+type Options struct {
+	// Format is one of json, yaml, wide, name, jsonpath=..., jsonpath-file=..., go-template=... or go-template-file=...
+	// The resources are printed as a table when empty.
+	Format string
+}
+
+// Flag returns the flag that sets the output format
+func (o *Options) Flag() cli.Flag {
+	return &cli.StringFlag{
+		Name:        "output",
+		Aliases:     []string{"o"},
+		Usage:       "Example: -output jsonpath='{.metadata.name}'",
+		DefaultText: "Output format. One of: json, yaml, wide, name, jsonpath=..., jsonpath-file=..., go-template=..., go-template-file=...",
+		Validator: func(val string) error {
+			if val == Wide {
+				return nil
+			}
+			_, err := printer(val)
+			return err
+		},
+		Destination: &o.Format,
+	}
+}
+
+// IsTable returns true when the resources are printed as a table
+func (o Options) IsTable() bool {
+	return len(o.Format) == 0 || o.Format == Wide
+}
+
+// Print writes the table in the requested format. Formats other than the table print the resources stored in the
+// rows, as a single object when single is true or as a v1 List otherwise.
+func (o Options) Print(w io.Writer, scheme *runtime.Scheme, table *metav1.Table, single bool) error {
+	if o.IsTable() {
+		return printers.NewTablePrinter(printers.PrintOptions{Wide: o.Format == Wide}).PrintObj(table, w)
+	}
+	p, err := printer(o.Format)
+	if err != nil {
+		return err
+	}
+	objs := []runtime.Object{}
+	for _, r := range table.Rows {
+		if r.Object.Object == nil {
+			continue
+		}
+		obj := r.Object.Object.DeepCopyObject()
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		objs = append(objs, obj)
+	}
+	// The name printer doesn't support lists, so the resources are printed one by one
+	if (single && len(objs) == 1) || o.Format == "name" {
+		for _, obj := range objs {
+			if err := p.PrintObj(obj, w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	list := &corev1.List{TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: "v1"}, Items: []runtime.RawExtension{}}
+	for _, obj := range objs {
+		list.Items = append(list.Items, runtime.RawExtension{Object: obj})
+	}
+	return p.PrintObj(list, w)
+}
+
+// printer returns the cli-runtime printer for the format, with the same syntax as kubectl
+func printer(format string) (printers.ResourcePrinter, error) {
+	name, arg, _ := strings.Cut(format, "=")
+	switch name {
+	case "json":
+		return &printers.JSONPrinter{}, nil
+	case "yaml":
+		return &printers.YAMLPrinter{}, nil
+	case "name":
+		return &printers.NamePrinter{}, nil
+	case "jsonpath", "jsonpath-file", "go-template", "go-template-file":
+		if len(arg) == 0 {
+			return nil, fmt.Errorf("template format specified but no template given: %s", format)
+		}
+		if strings.HasSuffix(name, "-file") {
+			b, err := os.ReadFile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("error reading template %s: %w", arg, err)
+			}
+			arg = string(b)
+		}
+		if strings.HasPrefix(name, "jsonpath") {
+			p, err := printers.NewJSONPathPrinter(arg)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing jsonpath %s: %w", arg, err)
+			}
+			p.AllowMissingKeys(true)
+			return p, nil
+		}
+		return printers.NewGoTemplatePrinter([]byte(arg))
+	}
+	return nil, fmt.Errorf("invalid output format %s: only 'json', 'yaml', 'wide', 'name', 'jsonpath=...', 'jsonpath-file=...', 'go-template=...' or 'go-template-file=...' are supported", format)
+}
@@ -0,0 +1,77 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/jordigilh/korn/cmd/get/output"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Printing the rows of get commands", func() {
+	var (
+		scheme *runtime.Scheme
+		table  *metav1.Table
+	)
+
+	newRow := func(name string) metav1.TableRow {
+		return metav1.TableRow{
+			Cells:  []interface{}{name, "extra"},
+			Object: runtime.RawExtension{Object: &applicationapiv1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}}},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(applicationapiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		table = &metav1.Table{
+			ColumnDefinitions: []metav1.TableColumnDefinition{
+				{Name: "Name", Type: "string"},
+				{Name: "Extra", Type: "string", Priority: 1},
+			},
+			Rows: []metav1.TableRow{newRow("alpha"), newRow("bravo")},
+		}
+	})
+
+	print := func(format string, single bool) string {
+		var b bytes.Buffer
+		Expect(output.Options{Format: format}.Print(&b, scheme, table, single)).To(Succeed())
+		return b.String()
+	}
+
+	It("should hide the wide columns in the default table", func() {
+		Expect(print("", false)).ToNot(ContainSubstring("EXTRA"))
+	})
+
+	It("should show the wide columns with the wide format", func() {
+		Expect(print(output.Wide, false)).To(ContainSubstring("EXTRA"))
+	})
+
+	It("should print the resources as a list in json", func() {
+		var list map[string]interface{}
+		Expect(json.Unmarshal([]byte(print("json", false)), &list)).To(Succeed())
+		Expect(list["kind"]).To(Equal("List"))
+		Expect(list["items"]).To(HaveLen(2))
+		item := list["items"].([]interface{})[0].(map[string]interface{})
+		Expect(item["kind"]).To(Equal("Application"))
+		Expect(item["apiVersion"]).To(Equal(applicationapiv1alpha1.GroupVersion.String()))
+	})
+
+	It("should print a single resource without the list", func() {
+		table.Rows = table.Rows[:1]
+		Expect(print("jsonpath={.metadata.name}", true)).To(Equal("alpha"))
+	})
+
+	It("should print the names of the resources", func() {
+		Expect(print("name", false)).To(Equal("application.appstudio.redhat.com/alpha\napplication.appstudio.redhat.com/bravo\n"))
+	})
+
+	It("should print an empty list when there are no rows", func() {
+		table.Rows = nil
+		Expect(print("yaml", false)).To(ContainSubstring("items: []"))
+	})
+})
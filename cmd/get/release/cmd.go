@@ -5,13 +5,15 @@ import (
 	"os"
 	"time"
 
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/cmd/get/filter"
+	"github.com/jordigilh/korn/cmd/get/output"
 	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
 	"github.com/urfave/cli/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
-	"k8s.io/cli-runtime/pkg/printers"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,10 +25,13 @@ var (
 			{Name: "Release Plan", Type: "string"},
 			{Name: "Status", Type: "string"},
 			{Name: "Age", Type: "string"},
+			{Name: "Pipeline Run", Type: "string", Priority: 1},
+			{Name: "Message", Type: "string", Priority: 1},
 		},
 	}
-	p    = printers.NewTablePrinter(printers.PrintOptions{})
-	korn = konflux.Korn{}
+	korn    = konflux.Korn{}
+	filters = filter.Options{}
+	outputs = output.Options{}
 )
 
 func GetCommand() *cli.Command {
@@ -44,7 +49,7 @@ func GetCommand() *cli.Command {
 			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
 			return ctx, nil
 		},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:        "application",
 				Aliases:     []string{"app"},
@@ -52,48 +57,69 @@ func GetCommand() *cli.Command {
 				DefaultText: "Application where the releases are derived from",
 				Destination: &korn.ApplicationName,
 			},
-		},
+			&cli.IntFlag{
+				Name:        "limit",
+				Usage:       "Example: -limit 10",
+				DefaultText: "Maximum number of releases to list once filtered and sorted, newest first by default",
+				Destination: &filters.Limit,
+			},
+			outputs.Flag(),
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
+		}, filters.Flags()...),
 		Description: "Retrieves a release or the list of components. If application is not provided, it will list all snapshots in the namespace",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
 			if len(korn.ReleaseName) == 0 {
 				l, err := korn.ListReleases()
 				if err != nil {
 					return err
 				}
-				print(l)
-				return nil
+				return print(l, false)
 			}
 			r, err := korn.GetRelease()
 			if err != nil {
 				return err
 			}
-			print([]releaseapiv1alpha1.Release{*r})
-			return nil
+			return print([]releaseapiv1alpha1.Release{*r}, true)
 		},
 	}
 }
 
-func print(comps []releaseapiv1alpha1.Release) {
-	rows := []metav1.TableRow{}
-	var relStatus string
+func print(comps []releaseapiv1alpha1.Release, single bool) error {
+	rows := []filter.Row{}
 	for _, v := range comps {
+		var relStatus, relMessage string
 		for _, c := range v.Status.Conditions {
 			if c.Type == "Released" {
 				relStatus = c.Reason
+				relMessage = c.Message
 				break
 			}
 		}
 		if v.CreationTimestamp.IsZero() {
 			continue
 		}
-		rows = append(rows, metav1.TableRow{Cells: []interface{}{
-			v.Name,
-			v.Spec.Snapshot,
-			v.Spec.ReleasePlan,
-			relStatus,
-			duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
-		}})
+		rows = append(rows, filter.Row{
+			TableRow: metav1.TableRow{Cells: []interface{}{
+				v.Name,
+				v.Spec.Snapshot,
+				v.Spec.ReleasePlan,
+				relStatus,
+				duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
+				v.Status.ManagedProcessing.PipelineRun,
+				relMessage,
+			}},
+			Object: &v,
+			Status: relStatus,
+		})
+	}
+	tableRows, err := filters.Apply(rows)
+	if err != nil {
+		return err
 	}
-	table.Rows = rows
-	p.PrintObj(table, os.Stdout)
+	table.Rows = tableRows
+	return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, single)
 }
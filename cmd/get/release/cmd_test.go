@@ -121,4 +121,51 @@ var _ = Describe("Get Release Command", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("Filter and sort flags", func() {
+		DescribeTable("should validate the flags",
+			func(args []string, expectError bool) {
+				ctx := testSetup.WithObjects(testutils.GetBasicReleases()...).WithKubeClient()
+
+				err := cmd.Run(ctx, append([]string{""}, args...))
+
+				if expectError {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry("with a label selector", []string{"-l", "appstudio.openshift.io/application=" + testutils.TestAppName}, false),
+			Entry("with a creation period", []string{"--since", "72h", "--until", "1h"}, false),
+			Entry("with a status and sort field", []string{"--status", "Succeeded", "--sort-by", "name"}, false),
+			Entry("with a limit applied after the filters", []string{"--status", "Succeeded", "--limit", "1"}, false),
+			Entry("with an invalid label selector", []string{"-l", "app in test"}, true),
+			Entry("with an invalid sort field", []string{"--sort-by", "size"}, true),
+			Entry("with an operator without version streams", []string{"--operator", "unknown-operator"}, true),
+		)
+	})
+
+	Context("Output flag", func() {
+		DescribeTable("should print the releases in the requested format",
+			func(args []string, expectError bool) {
+				ctx := testSetup.WithObjects(testutils.GetBasicReleases()...).WithKubeClient()
+
+				err := cmd.Run(ctx, append([]string{""}, args...))
+
+				if expectError {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry("in json", []string{"-o", "json"}, false),
+			Entry("in yaml", []string{"-o", "yaml"}, false),
+			Entry("with the wide columns", []string{"-o", "wide"}, false),
+			Entry("with their names", []string{"-o", "name"}, false),
+			Entry("with a jsonpath template", []string{"-o", "jsonpath={.items[*].metadata.name}"}, false),
+			Entry("with a go template", []string{"-o", "go-template={{range .items}}{{.metadata.name}}{{end}}"}, false),
+			Entry("with an unsupported format", []string{"-o", "xml"}, true),
+			Entry("with an invalid jsonpath template", []string{"-o", "jsonpath={.items[}"}, true),
+		)
+	})
 })
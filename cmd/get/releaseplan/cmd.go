@@ -5,6 +5,9 @@ import (
 	"os"
 	"time"
 
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/cmd/get/filter"
+	"github.com/jordigilh/korn/cmd/get/output"
 	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
@@ -12,7 +15,6 @@ import (
 	"github.com/urfave/cli/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
-	"k8s.io/cli-runtime/pkg/printers"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,12 +25,14 @@ var (
 			{Name: "Application", Type: "string"},
 			{Name: "Environment", Type: "string"},
 			{Name: "Release Plan Admission", Type: "string"},
-			{Name: "Active", Type: "string"},
+			{Name: "Status", Type: "string"},
 			{Name: "Age", Type: "string"},
+			{Name: "Target", Type: "string", Priority: 1},
 		},
 	}
-	p    = printers.NewTablePrinter(printers.PrintOptions{})
-	korn = konflux.Korn{}
+	korn    = konflux.Korn{}
+	filters = filter.Options{}
+	outputs = output.Options{}
 )
 
 func GetCommand() *cli.Command {
@@ -46,7 +50,7 @@ func GetCommand() *cli.Command {
 			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
 			return ctx, nil
 		},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:        "application",
 				Aliases:     []string{"app"},
@@ -54,29 +58,33 @@ func GetCommand() *cli.Command {
 				DefaultText: "Application where the release plans belong to",
 				Destination: &korn.ApplicationName,
 			},
-		},
+			outputs.Flag(),
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
+		}, filters.Flags()...),
 		Description: "Retrieves a release plan. If application is not provided, it will list all plans in the namespace",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
 			if len(korn.ReleasePlanName) == 0 {
 				l, err := korn.ListReleasePlans()
 				if err != nil {
 					return err
 				}
-				print(l)
-				return nil
+				return print(l, false)
 			}
 			r, err := korn.GetReleasePlan()
 			if err != nil {
 				return err
 			}
-			print([]releaseapiv1alpha1.ReleasePlan{*r})
-			return nil
+			return print([]releaseapiv1alpha1.ReleasePlan{*r}, true)
 		},
 	}
 }
 
-func print(releasePlans []releaseapiv1alpha1.ReleasePlan) {
-	rows := []metav1.TableRow{}
+func print(releasePlans []releaseapiv1alpha1.ReleasePlan, single bool) error {
+	rows := []filter.Row{}
 	for _, v := range releasePlans {
 		if v.CreationTimestamp.IsZero() {
 			continue
@@ -85,15 +93,28 @@ func print(releasePlans []releaseapiv1alpha1.ReleasePlan) {
 			logrus.Debugf("ReleasePlan %s has no labels", v.Name)
 			continue
 		}
-		rows = append(rows, metav1.TableRow{Cells: []interface{}{
-			v.Name,
-			v.Spec.Application,
-			v.Labels[konflux.EnvironmentLabel],
-			v.Status.ReleasePlanAdmission.Name,
-			v.Status.ReleasePlanAdmission.Active,
-			duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
-		}})
+		status := "Inactive"
+		if v.Status.ReleasePlanAdmission.Active {
+			status = "Active"
+		}
+		rows = append(rows, filter.Row{
+			TableRow: metav1.TableRow{Cells: []interface{}{
+				v.Name,
+				v.Spec.Application,
+				v.Labels[konflux.EnvironmentLabel],
+				v.Status.ReleasePlanAdmission.Name,
+				status,
+				duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
+				v.Spec.Target,
+			}},
+			Object: &v,
+			Status: status,
+		})
+	}
+	tableRows, err := filters.Apply(rows)
+	if err != nil {
+		return err
 	}
-	table.Rows = rows
-	p.PrintObj(table, os.Stdout)
+	table.Rows = tableRows
+	return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, single)
 }
@@ -0,0 +1,118 @@
+package releaseplanadmission
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/cmd/get/filter"
+	"github.com/jordigilh/korn/cmd/get/output"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	table = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Target", Type: "string"},
+			{Name: "Applications", Type: "string"},
+			{Name: "Policy", Type: "string"},
+			{Name: "Pipeline", Type: "string"},
+			{Name: "Age", Type: "string"},
+			{Name: "Origin", Type: "string", Priority: 1},
+			{Name: "Service Account", Type: "string", Priority: 1},
+		},
+	}
+	korn    = konflux.Korn{}
+	filters = filter.Options{}
+	outputs = output.Options{}
+)
+
+func GetCommand() *cli.Command {
+
+	return &cli.Command{
+		Name:    "releaseplanadmission",
+		Aliases: []string{"rpa", "releaseplanadmissions"},
+		Usage:   "get releaseplanadmissions",
+		Arguments: []cli.Argument{&cli.StringArg{
+			Name:        "releasePlanAdmission",
+			Destination: &korn.ReleasePlanAdmissionName,
+		}},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			return ctx, nil
+		},
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:        "application",
+				Aliases:     []string{"app"},
+				Usage:       "Example: -application my-application",
+				DefaultText: "Application whose release plans are matched by the admissions",
+				Destination: &korn.ApplicationName,
+			},
+			outputs.Flag(),
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
+		}, filters.Flags()...),
+		Description: "Retrieves a release plan admission by name, as in 'managed-namespace/name'. If no name is provided, it lists the admissions matched by the release plans of the application, or of all the release plans in the namespace",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
+			if len(korn.ReleasePlanAdmissionName) == 0 {
+				l, err := korn.ListReleasePlanAdmissions()
+				if err != nil {
+					return err
+				}
+				return print(l, false)
+			}
+			r, err := korn.GetReleasePlanAdmission()
+			if err != nil {
+				return err
+			}
+			return print([]releaseapiv1alpha1.ReleasePlanAdmission{*r}, true)
+		},
+	}
+}
+
+func print(rpas []releaseapiv1alpha1.ReleasePlanAdmission, single bool) error {
+	rows := []filter.Row{}
+	for _, v := range rpas {
+		if v.CreationTimestamp.IsZero() {
+			continue
+		}
+		var serviceAccount string
+		if v.Spec.Pipeline != nil {
+			serviceAccount = v.Spec.Pipeline.ServiceAccountName
+		}
+		rows = append(rows, filter.Row{
+			TableRow: metav1.TableRow{Cells: []interface{}{
+				v.Name,
+				v.Namespace,
+				strings.Join(v.Spec.Applications, ","),
+				v.Spec.Policy,
+				konflux.GetReleasePlanAdmissionPipeline(v),
+				duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
+				v.Spec.Origin,
+				serviceAccount,
+			}},
+			Object: &v,
+			Status: filter.LatestConditionReason(v.Status.Conditions),
+		})
+	}
+	tableRows, err := filters.Apply(rows)
+	if err != nil {
+		return err
+	}
+	table.Rows = tableRows
+	return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, single)
+}
@@ -0,0 +1,50 @@
+package releaseplanadmission_test
+
+import (
+	"github.com/jordigilh/korn/cmd/get/releaseplanadmission"
+	"github.com/jordigilh/korn/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Get ReleasePlanAdmission Command", func() {
+	var (
+		testSetup *testutils.TestSetup
+		cmd       *cli.Command
+	)
+
+	BeforeEach(func() {
+		testSetup = testutils.NewTestSetup(createFakeScheme())
+		cmd = releaseplanadmission.GetCommand()
+	})
+
+	admitted := func() []runtime.Object {
+		return []runtime.Object{
+			testutils.NewAdmittedReleasePlan(testutils.TestReleasePlan, testutils.TestNamespace, testutils.TestAppName, testutils.TestReleasePlanAdmission),
+			testutils.NewReleasePlanAdmission(testutils.TestReleasePlanAdmission, testutils.TestAppName),
+		}
+	}
+
+	DescribeTable("should retrieve the admissions",
+		func(objects []runtime.Object, args []string, expectError bool) {
+			ctx := testSetup.WithObjects(objects...).WithKubeClient()
+
+			err := cmd.Run(ctx, append([]string{""}, args...))
+
+			if expectError {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+			}
+		},
+		Entry("matched by the release plans in the namespace", admitted(), []string{}, false),
+		Entry("matched by the release plans of the application", admitted(), []string{"--app", testutils.TestAppName}, false),
+		Entry("when no release plan is matched",
+			[]runtime.Object{testutils.NewStagingReleasePlan(testutils.TestReleasePlan, testutils.TestNamespace, testutils.TestAppName)}, []string{}, false),
+		Entry("by namespaced name", admitted(), []string{testutils.TestManagedNamespace + "/" + testutils.TestReleasePlanAdmission, "-o", "yaml"}, false),
+		Entry("by name in the current namespace", admitted(), []string{testutils.TestReleasePlanAdmission}, true),
+		Entry("when the matched admission doesn't exist", admitted()[:1], []string{}, true),
+	)
+})
@@ -2,16 +2,24 @@ package snapshot
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"time"
 
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/cmd/get/filter"
+	"github.com/jordigilh/korn/cmd/get/output"
 	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,11 +31,27 @@ var (
 			{Name: "SHA", Type: "string"},
 			{Name: "Commit", Type: "string"},
 			{Name: "Status", Type: "string"},
+			{Name: "Approval", Type: "string"},
+			{Name: "Korn", Type: "string"},
 			{Name: "Age", Type: "string"},
+			{Name: "Branch", Type: "string", Priority: 1},
+			{Name: "Components", Type: "integer", Priority: 1},
 		},
 	}
-	p    = printers.NewTablePrinter(printers.PrintOptions{})
-	korn = konflux.Korn{}
+	componentsTable = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Component", Type: "string"},
+			{Name: "Version", Type: "string"},
+			{Name: "Release", Type: "string"},
+			{Name: "Image", Type: "string"},
+		},
+	}
+	p               = printers.NewTablePrinter(printers.PrintOptions{})
+	korn            = konflux.Korn{}
+	filters         = filter.Options{}
+	outputs         = output.Options{}
+	watchSnapshots  bool
+	exitOnCandidate bool
 )
 
 func GetCommand() *cli.Command {
@@ -45,9 +69,11 @@ func GetCommand() *cli.Command {
 			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
 			korn.PodClient = ctx.Value(internal.PodmanCliCtxType).(internal.ImageClient)
 			korn.GitClient = ctx.Value(internal.GitCliCtxType).(internal.GitCommitVersioner)
+			korn.KornVersion, _ = ctx.Value(internal.VersionCtxType).(string)
+			korn.DynamicClient, _ = ctx.Value(internal.DynamicCliCtxType).(dynamic.Interface)
 			return ctx, nil
 		},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:        "application",
 				Aliases:     []string{"app"},
@@ -64,7 +90,7 @@ func GetCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:        "version",
 				Usage:       "Example: -version v0.0.11",
-				DefaultText: "Retrieves the latest snapshot that matches the given version in the bundle's label",
+				DefaultText: "Retrieves the latest snapshot that matches the given version in the bundle's label. With -operator, a major and minor version such as 1.1 only selects the version stream",
 				Destination: &korn.Version,
 			},
 			&cli.BoolFlag{
@@ -74,56 +100,170 @@ func GetCommand() *cli.Command {
 				DefaultText: "Filters the snapshots that are suitable for the next release. The cutoff snapshot is the last used in a successful release",
 				Destination: &korn.Candidate,
 			},
-		},
+			&cli.IntFlag{
+				Name:        "limit",
+				Usage:       "Example: -limit 10",
+				DefaultText: "Maximum number of snapshots to list once filtered and sorted, newest first by default",
+				Destination: &filters.Limit,
+			},
+			&cli.StringFlag{
+				Name:        "branch",
+				Usage:       "Example: -candidate -branch release-1.0",
+				DefaultText: "Only considers the snapshots built from this git branch. Defaults to the branch in the application's korn.redhat.io/branch annotation",
+				Destination: &korn.Branch,
+			},
+			&cli.BoolFlag{
+				Name:        "watch",
+				Aliases:     []string{"w"},
+				Usage:       "Example: -application my-application -watch",
+				DefaultText: "Watches the snapshots created after the command starts and the changes in the status of their tests. Combined with -candidate, each snapshot is validated once its tests finish",
+				Destination: &watchSnapshots,
+			},
+			&cli.BoolFlag{
+				Name:        "exit-on-candidate",
+				Usage:       "Example: -watch -candidate -exit-on-candidate",
+				DefaultText: "Stops watching when the first valid candidate is found",
+				Destination: &exitOnCandidate,
+			},
+			flags.WorkersFlag(&korn),
+			&cli.BoolFlag{
+				Name:        "record-verdicts",
+				Usage:       "Example: -candidate -record-verdicts",
+				DefaultText: "Stores the candidacy verdict of each validated snapshot in its annotations",
+				Destination: &korn.RecordVerdicts,
+			},
+			&cli.DurationFlag{
+				Name:        "trust-verdicts",
+				Usage:       "Example: -candidate -trust-verdicts 24h",
+				DefaultText: "Reuses the verdicts recorded in the snapshots by the same korn version and validation options within the given period instead of validating them again",
+				Destination: &korn.TrustVerdictsFor,
+			},
+			&cli.BoolFlag{
+				Name:        "require-approval",
+				Usage:       "Example: -candidate -require-approval",
+				DefaultText: "Only considers the snapshots that have been manually approved as candidates",
+				Destination: &korn.RequireApproval,
+			},
+			&cli.BoolFlag{
+				Name:        "compare-release",
+				Usage:       "Example: -candidate -compare-release",
+				DefaultText: "Requires the release label of each component image to match the one in the bundle when validating candidates",
+				Destination: &korn.CompareReleaseLabel,
+			},
+			outputs.Flag(),
+			flags.OperatorFlag(&korn),
+		}, filters.Flags()...),
 		Description: "Retrieves a snapshot or the list of components. If application or version is not provided, it will list all snapshots in the namespace",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
 			switch {
+			case watchSnapshots:
+				if exitOnCandidate && !korn.Candidate {
+					return errors.New("exit-on-candidate requires the candidate flag")
+				}
+				var printed bool
+				return korn.WatchSnapshots(ctx, func(e konflux.SnapshotEvent) bool {
+					if err := printEvent(e, !printed); err != nil {
+						logrus.Errorf("failed to print snapshot %s: %v", e.Snapshot.Name, err)
+					}
+					printed = true
+					return exitOnCandidate && e.Verdict != nil && e.Verdict.Valid
+				})
 			case len(korn.SnapshotName) != 0 || len(korn.SHA) > 0:
 				s, err := korn.GetSnapshot()
 				if err != nil {
 					return err
 				}
-				print([]applicationapiv1alpha1.Snapshot{*s})
+				return print([]applicationapiv1alpha1.Snapshot{*s}, true)
 			case korn.Candidate:
 				snapshot, err := korn.GetSnapshotCandidateForRelease()
 				if err != nil {
 					return err
 				}
-				print([]applicationapiv1alpha1.Snapshot{*snapshot})
+				if err := print([]applicationapiv1alpha1.Snapshot{*snapshot}, true); err != nil {
+					return err
+				}
+				if !outputs.IsTable() {
+					return nil
+				}
+				versions, err := korn.GetComponentVersions(*snapshot)
+				if err != nil {
+					return err
+				}
+				printComponentVersions(versions)
 			default:
 				l, err := korn.ListSnapshots()
 				if err != nil {
 					return err
 				}
-				print(l)
+				return print(l, false)
 			}
 			return nil
 		},
 	}
 }
 
-func print(snapshots []applicationapiv1alpha1.Snapshot) {
-	rows := []metav1.TableRow{}
-	var status string
+func print(snapshots []applicationapiv1alpha1.Snapshot, single bool) error {
+	rows := []filter.Row{}
 	for _, v := range snapshots {
-		for _, c := range v.Status.Conditions {
-			if c.Type == "AppStudioTestSucceeded" {
-				status = c.Reason
-				break
-			}
-		}
 		if v.CreationTimestamp.IsZero() {
 			continue
 		}
+		rows = append(rows, filter.Row{TableRow: snapshotRow(v, konflux.SnapshotVerdictStatus(v)), Object: &v, Status: konflux.SnapshotTestStatus(v)})
+	}
+	tableRows, err := filters.Apply(rows)
+	if err != nil {
+		return err
+	}
+	table.Rows = tableRows
+	return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, single)
+}
+
+// printEvent prints the snapshot in the event, with the verdict of its validation when available. Formats other than
+// the table print each snapshot as a single object.
+func printEvent(e konflux.SnapshotEvent, withHeaders bool) error {
+	verdict := konflux.SnapshotVerdictStatus(e.Snapshot)
+	if e.Verdict != nil {
+		verdict = konflux.VerdictStatus(*e.Verdict)
+	}
+	row := snapshotRow(e.Snapshot, verdict)
+	row.Object = runtime.RawExtension{Object: &e.Snapshot}
+	table.Rows = []metav1.TableRow{row}
+	if !outputs.IsTable() {
+		return outputs.Print(os.Stdout, korn.KubeClient.Scheme(), table, true)
+	}
+	printer := printers.NewTablePrinter(printers.PrintOptions{NoHeaders: !withHeaders, Wide: outputs.Format == output.Wide})
+	return printer.PrintObj(table, os.Stdout)
+}
+
+func snapshotRow(v applicationapiv1alpha1.Snapshot, verdict string) metav1.TableRow {
+	return metav1.TableRow{Cells: []interface{}{
+		v.Name,
+		v.Spec.Application,
+		v.Labels["pac.test.appstudio.openshift.io/sha"],
+		v.Annotations["pac.test.appstudio.openshift.io/sha-title"],
+		konflux.SnapshotTestStatus(v),
+		konflux.SnapshotApprovalStatus(v),
+		verdict,
+		duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
+		konflux.GetSnapshotBranch(v),
+		len(v.Spec.Components),
+	}}
+}
+
+func printComponentVersions(versions []konflux.ComponentVersion) {
+	rows := []metav1.TableRow{}
+	for _, v := range versions {
 		rows = append(rows, metav1.TableRow{Cells: []interface{}{
 			v.Name,
-			v.Spec.Application,
-			v.Labels["pac.test.appstudio.openshift.io/sha"],
-			v.Annotations["pac.test.appstudio.openshift.io/sha-title"],
-			status,
-			duration.HumanDuration(time.Since(v.CreationTimestamp.Time)),
+			v.Version,
+			v.Release,
+			v.Image,
 		}})
 	}
-	table.Rows = rows
-	p.PrintObj(table, os.Stdout)
+	componentsTable.Rows = rows
+	fmt.Println()
+	p.PrintObj(componentsTable, os.Stdout)
 }
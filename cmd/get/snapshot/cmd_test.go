@@ -468,6 +468,14 @@ var _ = Describe("Get Snapshot Command", func() {
 
 	})
 
+	Context("Watch snapshots with --watch flag", func() {
+		It("should fail when exiting on candidate without the candidate flag", func() {
+			args := []string{"", "--app", "test-app", "--watch", "--exit-on-candidate"}
+			err := cmd.Run(ctx, args)
+			Expect(err).To(MatchError("exit-on-candidate requires the candidate flag"))
+		})
+	})
+
 	Context("Get specific snapshot by name", func() {
 		DescribeTable("should get snapshot by name",
 			func(snapshots []runtime.Object, snapshotName string, expectError bool, description string) {
@@ -536,6 +544,7 @@ var _ = Describe("Get Snapshot Command", func() {
 		},
 			Entry("application flag", "application", "app"),
 			Entry("candidate flag", "candidate", "c"),
+			Entry("output flag", "output", "o"),
 		)
 	})
 
@@ -565,6 +574,10 @@ func (m *mockGitClient) GetVersion(repoURL, commitHash string) (*semver.Version,
 	return &version, nil
 }
 
+func (m *mockGitClient) GetCommitTitle(repoURL, commitHash string) (string, error) {
+	return "Commit " + commitHash, nil
+}
+
 func (m *mockGitClient) Cleanup() {
 	// No cleanup needed for mock
 	fmt.Print("Cleanup called")
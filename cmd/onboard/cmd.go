@@ -0,0 +1,101 @@
+package onboard
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	table = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Kind", Type: "string"},
+			{Name: "Name", Type: "string"},
+			{Name: "Label", Type: "string"},
+			{Name: "Reason", Type: "string"},
+		},
+	}
+	p    = printers.NewTablePrinter(printers.PrintOptions{})
+	korn = konflux.Korn{}
+	yes  bool
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "onboard",
+		Usage: "label an application, its components and release plans for korn",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			korn.PodClient = ctx.Value(internal.PodmanCliCtxType).(internal.ImageClient)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "application",
+				Aliases:     []string{"app"},
+				Usage:       "Example: -application my-application",
+				DefaultText: "Application to onboard",
+				Required:    true,
+				Destination: &korn.ApplicationName,
+			},
+			&cli.BoolFlag{
+				Name:        "yes",
+				Aliases:     []string{"y"},
+				Usage:       "Applies the proposed labels without asking for confirmation",
+				Destination: &yes,
+			},
+		},
+		Description: "Infers the korn labels missing in the application, its components and release plans: the application type and bundle component from the names of the components and the labels of their images in the latest snapshot, the bundle label of each component from the labels of the bundle image, and the environments from the names of the release plans. The proposed labels are applied after confirmation. Labels already set are never changed",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			patches, err := korn.ProposeOnboarding()
+			if err != nil {
+				return err
+			}
+			if len(patches) == 0 {
+				logrus.Infof("No labels to add to application %s", korn.ApplicationName)
+				return nil
+			}
+			if err := print(os.Stdout, patches); err != nil {
+				return err
+			}
+			if !yes && !confirm(cmd.Root().Reader, os.Stdout) {
+				logrus.Info("No labels applied")
+				return nil
+			}
+			if err := korn.ApplyOnboarding(patches); err != nil {
+				return err
+			}
+			logrus.Infof("%d labels applied, run 'korn doctor --app %s' to check the onboarding", len(patches), korn.ApplicationName)
+			return nil
+		},
+	}
+}
+
+func print(out io.Writer, patches []konflux.LabelPatch) error {
+	rows := []metav1.TableRow{}
+	for _, v := range patches {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{v.Kind, v.Object.GetName(), v.Key + "=" + v.Value, v.Reason}})
+	}
+	table.Rows = rows
+	return p.PrintObj(table, out)
+}
+
+// confirm asks whether to apply the labels and returns true when the answer is yes
+func confirm(in io.Reader, out io.Writer) bool {
+	fmt.Fprint(out, "Apply these labels? [y/N]: ")
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,69 @@
+package onboard_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jordigilh/korn/cmd/onboard"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Onboard Command", func() {
+	var (
+		createTestSetup *testutils.CreateTestSetup
+		cmd             *cli.Command
+	)
+
+	BeforeEach(func() {
+		var err error
+		createTestSetup, err = testutils.NewCreateTestSetup(createFakeScheme())
+		Expect(err).ToNot(HaveOccurred())
+		createTestSetup.WithObjects(
+			testutils.NewApplication(testutils.TestAppName, testutils.TestNamespace, nil),
+			testutils.NewComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+		)
+		cmd = onboard.Command()
+	})
+
+	AfterEach(func() {
+		createTestSetup.Cleanup()
+	})
+
+	applicationType := func(ctx context.Context) string {
+		app := applicationapiv1alpha1.Application{}
+		kubeClient := ctx.Value(internal.KubeCliCtxType).(client.Client)
+		Expect(kubeClient.Get(context.TODO(), client.ObjectKey{Namespace: testutils.TestNamespace, Name: testutils.TestAppName}, &app)).To(Succeed())
+		return app.Labels[konflux.ApplicationTypeLabel]
+	}
+
+	DescribeTable("should label the application",
+		func(args []string, input string, expected string) {
+			ctx := createTestSetup.WithKubeClientAndMocks()
+			cmd.Reader = strings.NewReader(input)
+
+			err := cmd.Run(ctx, append([]string{"onboard", "--app", testutils.TestAppName}, args...))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(applicationType(ctx)).To(Equal(expected))
+		},
+		Entry("when confirmed", []string{}, "y\n", "operator"),
+		Entry("without confirmation with --yes", []string{"--yes"}, "", "operator"),
+		Entry("unless the labels are rejected", []string{}, "n\n", ""),
+		Entry("unless there is no answer", []string{}, "", ""),
+	)
+
+	It("should fail when the application doesn't exist", func() {
+		ctx := createTestSetup.WithKubeClientAndMocks()
+
+		err := cmd.Run(ctx, []string{"onboard", "--app", "missing-app", "--yes"})
+
+		Expect(err).To(MatchError(ContainSubstring("application missing-app not found")))
+	})
+})
@@ -0,0 +1,48 @@
+package approve
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	korn = konflux.Korn{}
+)
+
+func ApproveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "approve",
+		Usage: "approve a snapshot for release",
+		Arguments: []cli.Argument{&cli.StringArg{
+			Name:        "snapshot",
+			Destination: &korn.SnapshotName,
+		}},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "reason",
+				Usage:       "Example: -reason \"verified by QA\"",
+				DefaultText: "Reason why the snapshot is approved",
+				Destination: &korn.ApprovalReason,
+			},
+		},
+		Description: "Approves a snapshot for release. Approved snapshots can be released to environments whose release plan requires approval, and a previous rejection is lifted",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			s, err := korn.ApproveSnapshot()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Snapshot %s/%s approved\n", s.Namespace, s.Name)
+			return nil
+		},
+	}
+}
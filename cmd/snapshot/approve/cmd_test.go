@@ -0,0 +1,56 @@
+package approve_test
+
+import (
+	"context"
+
+	"github.com/jordigilh/korn/cmd/snapshot/approve"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Approve Snapshot Command", func() {
+	var (
+		setup *testutils.TestSetup
+		cmd   *cli.Command
+	)
+
+	BeforeEach(func() {
+		setup = testutils.NewTestSetup(createFakeScheme())
+		setup.FakeClientBuilder = setup.FakeClientBuilder.WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", testutils.FilterBySnapshotName)
+		cmd = approve.ApproveCommand()
+	})
+
+	It("should label the snapshot as approved", func() {
+		rejected := testutils.NewTestSnapshot()
+		rejected.Labels[konflux.ApprovalLabel] = string(konflux.RejectedState)
+		ctx := setup.WithObjects(rejected).WithKubeClient()
+		kubeClient := ctx.Value(internal.KubeCliCtxType).(client.Client)
+
+		err := cmd.Run(ctx, []string{"approve", testutils.TestSnapshotName, "--reason", "verified by QA"})
+
+		Expect(err).ToNot(HaveOccurred())
+		s := applicationapiv1alpha1.Snapshot{}
+		Expect(kubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testutils.TestNamespace, Name: testutils.TestSnapshotName}, &s)).To(Succeed())
+		Expect(konflux.GetSnapshotApproval(s)).To(Equal(konflux.ApprovedState))
+		Expect(s.Annotations).To(HaveKeyWithValue(konflux.ApprovalReasonAnnotation, "verified by QA"))
+	})
+
+	DescribeTable("should fail to approve",
+		func(args []string) {
+			ctx := setup.WithObjects(testutils.NewTestSnapshot()).WithKubeClient()
+
+			err := cmd.Run(ctx, args)
+
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("without a snapshot name", []string{"approve"}),
+		Entry("a snapshot that does not exist", []string{"approve", "missing-snapshot"}),
+	)
+})
@@ -0,0 +1,18 @@
+package snapshot
+
+import (
+	"github.com/jordigilh/korn/cmd/snapshot/approve"
+	"github.com/jordigilh/korn/cmd/snapshot/reject"
+	"github.com/urfave/cli/v3"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "snapshot",
+		Usage: "snapshot approve|reject",
+		Commands: []*cli.Command{
+			approve.ApproveCommand(),
+			reject.RejectCommand(),
+		},
+	}
+}
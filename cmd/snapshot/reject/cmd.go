@@ -0,0 +1,49 @@
+package reject
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	korn = konflux.Korn{}
+)
+
+func RejectCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "reject",
+		Usage: "reject a snapshot for release",
+		Arguments: []cli.Argument{&cli.StringArg{
+			Name:        "snapshot",
+			Destination: &korn.SnapshotName,
+		}},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "reason",
+				Usage:       "Example: -reason \"fails upgrade tests\"",
+				DefaultText: "Reason why the snapshot is rejected",
+				Required:    true,
+				Destination: &korn.ApprovalReason,
+			},
+		},
+		Description: "Rejects a snapshot so that it is never selected as a candidate for release, even when it passes all the validation rules",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			s, err := korn.RejectSnapshot()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Snapshot %s/%s rejected\n", s.Namespace, s.Name)
+			return nil
+		},
+	}
+}
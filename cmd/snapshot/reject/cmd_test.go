@@ -0,0 +1,56 @@
+package reject_test
+
+import (
+	"context"
+
+	"github.com/jordigilh/korn/cmd/snapshot/reject"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Reject Snapshot Command", func() {
+	var (
+		setup *testutils.TestSetup
+		cmd   *cli.Command
+	)
+
+	BeforeEach(func() {
+		setup = testutils.NewTestSetup(createFakeScheme())
+		setup.FakeClientBuilder = setup.FakeClientBuilder.WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", testutils.FilterBySnapshotName)
+		cmd = reject.RejectCommand()
+	})
+
+	It("should label the snapshot as rejected with the reason", func() {
+		ctx := setup.WithObjects(testutils.NewTestSnapshot()).WithKubeClient()
+		kubeClient := ctx.Value(internal.KubeCliCtxType).(client.Client)
+
+		err := cmd.Run(ctx, []string{"reject", testutils.TestSnapshotName, "--reason", "fails upgrade tests"})
+
+		Expect(err).ToNot(HaveOccurred())
+		s := applicationapiv1alpha1.Snapshot{}
+		Expect(kubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testutils.TestNamespace, Name: testutils.TestSnapshotName}, &s)).To(Succeed())
+		Expect(konflux.GetSnapshotApproval(s)).To(Equal(konflux.RejectedState))
+		Expect(s.Annotations).To(HaveKeyWithValue(konflux.ApprovalReasonAnnotation, "fails upgrade tests"))
+		Expect(s.Annotations).To(HaveKey(konflux.ApprovalTimestampAnnotation))
+	})
+
+	DescribeTable("should fail to reject",
+		func(args []string) {
+			ctx := setup.WithObjects(testutils.NewTestSnapshot()).WithKubeClient()
+
+			err := cmd.Run(ctx, args)
+
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("without a reason", []string{"reject", testutils.TestSnapshotName}),
+		Entry("without a snapshot name", []string{"reject", "--reason", "broken"}),
+		Entry("a snapshot that does not exist", []string{"reject", "missing-snapshot", "--reason", "broken"}),
+	)
+})
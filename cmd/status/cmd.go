@@ -0,0 +1,116 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jordigilh/korn/cmd/flags"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/urfave/cli/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	table = &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Environment", Type: "string"},
+			{Name: "Release Plan", Type: "string"},
+			{Name: "Latest Release", Type: "string"},
+			{Name: "Result", Type: "string"},
+			{Name: "Age", Type: "string"},
+			{Name: "Deployed Snapshot", Type: "string"},
+			{Name: "Version", Type: "string"},
+		},
+	}
+	p    = printers.NewTablePrinter(printers.PrintOptions{})
+	korn = konflux.Korn{Workers: konflux.DefaultWorkers}
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "status",
+		Usage: "show where an application stands",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			korn.PodClient = ctx.Value(internal.PodmanCliCtxType).(internal.ImageClient)
+			korn.GitClient = ctx.Value(internal.GitCliCtxType).(internal.GitCommitVersioner)
+			return ctx, nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "application",
+				Aliases:     []string{"app"},
+				Usage:       "Example: -application my-application",
+				DefaultText: "Application to show",
+				Destination: &korn.ApplicationName,
+			},
+			flags.OperatorFlag(&korn),
+			flags.VersionFlag(&korn),
+		},
+		Description: "Shows the newest snapshot of the application and the status of its tests, the snapshot that would be released next, and the latest release to each environment with its result, the snapshot deployed and its version. Reports the environments that run a newer snapshot than the next one in the promotion order of the application, defined by its korn.redhat.io/promotion-order annotation or staging,production by default",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := flags.ResolveStream(&korn); err != nil {
+				return err
+			}
+			if korn.ApplicationName == "" {
+				return fmt.Errorf("application name is required")
+			}
+			status, err := korn.GetApplicationStatus()
+			if err != nil {
+				return err
+			}
+			return print(os.Stdout, *status)
+		},
+	}
+}
+
+// print writes the snapshots of the application followed by a table with the releases to each environment
+func print(out io.Writer, status konflux.ApplicationStatus) error {
+	fmt.Fprintf(out, "Application:        %s (%s)\n", status.Application.Name, status.Application.Labels[konflux.ApplicationTypeLabel])
+	latest := "none"
+	if s := status.LatestSnapshot; s != nil {
+		tests := konflux.SnapshotTestStatus(*s)
+		if len(tests) == 0 {
+			tests = "Unknown"
+		}
+		latest = fmt.Sprintf("%s (tests: %s, %s ago)", s.Name, tests, duration.HumanDuration(time.Since(s.CreationTimestamp.Time)))
+	}
+	fmt.Fprintf(out, "Latest snapshot:    %s\n", latest)
+	candidate := fmt.Sprintf("none (%s)", status.CandidateError)
+	if status.Candidate != nil {
+		candidate = status.Candidate.Name
+	}
+	fmt.Fprintf(out, "Release candidate:  %s\n", candidate)
+	for _, promotion := range status.PendingPromotions {
+		fmt.Fprintf(out, "Environment %s is ahead of %s\n", promotion.From, promotion.To)
+	}
+	fmt.Fprintln(out)
+
+	if len(status.Environments) == 0 {
+		fmt.Fprintf(out, "No environments found: label the release plans of application %s with %s=<environment>\n", status.Application.Name, konflux.EnvironmentLabel)
+		return nil
+	}
+	rows := []metav1.TableRow{}
+	for _, e := range status.Environments {
+		var release, result, age, deployed string
+		if r := e.LatestRelease; r != nil {
+			release = r.Name
+			result = konflux.ReleaseResult(*r)
+			age = duration.HumanDuration(time.Since(r.CreationTimestamp.Time))
+		}
+		if e.Deployed != nil {
+			deployed = e.Deployed.Name
+		}
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{e.Name, e.ReleasePlan.Name, release, result, age, deployed, e.Version}})
+	}
+	table.Rows = rows
+	return p.PrintObj(table, out)
+}
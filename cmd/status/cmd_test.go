@@ -0,0 +1,55 @@
+package status_test
+
+import (
+	"github.com/jordigilh/korn/cmd/status"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Status Command", func() {
+	var (
+		createTestSetup *testutils.CreateTestSetup
+		cmd             *cli.Command
+	)
+
+	BeforeEach(func() {
+		var err error
+		createTestSetup, err = testutils.NewCreateTestSetup(createFakeScheme())
+		Expect(err).ToNot(HaveOccurred())
+		createTestSetup.FakeClientBuilder = createTestSetup.FakeClientBuilder.WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", testutils.FilterBySnapshotName)
+		cmd = status.Command()
+	})
+
+	AfterEach(func() {
+		createTestSetup.Cleanup()
+	})
+
+	It("should show the status of an application", func() {
+		createTestSetup.WithObjects(append(testutils.GetCompleteCreateReleaseTestSet(),
+			testutils.NewSuccessfulRelease(testutils.TestReleaseName, testutils.TestNamespace, testutils.TestSnapshotName, testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName),
+		)...)
+		ctx := createTestSetup.WithKubeClientAndMocks()
+
+		err := cmd.Run(ctx, []string{"status", "--app", testutils.TestAppName})
+
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable("should fail",
+		func(objs []runtime.Object, args []string, expected string) {
+			createTestSetup.WithObjects(objs...)
+			ctx := createTestSetup.WithKubeClientAndMocks()
+
+			err := cmd.Run(ctx, append([]string{"status"}, args...))
+
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+		Entry("without an application", nil, []string{}, "application name is required"),
+		Entry("when the application doesn't exist", nil, []string{"--app", testutils.TestAppName}, "application test-app not found"),
+		Entry("when the operator and the application are set", nil, []string{"--app", testutils.TestAppName, "--operator", "my-operator"}, "mutually exclusive"),
+	)
+})
@@ -2,15 +2,17 @@ package waitfor
 
 import (
 	"github.com/jordigilh/korn/cmd/waitfor/release"
+	"github.com/jordigilh/korn/cmd/waitfor/snapshot"
 	"github.com/urfave/cli/v3"
 )
 
 func Command() *cli.Command {
 	return &cli.Command{
 		Name:  "waitfor",
-		Usage: "waitfor",
+		Usage: "waitfor release|snapshot",
 		Commands: []*cli.Command{
 			release.WaitForCommand(),
+			snapshot.WaitForCommand(),
 		},
 	}
 }
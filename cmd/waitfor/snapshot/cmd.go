@@ -0,0 +1,69 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/urfave/cli/v3"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	korn = konflux.Korn{}
+)
+
+func WaitForCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "snapshot",
+		Aliases:   []string{"snapshots"},
+		Usage:     "waitfor snapshot --sha <commit_sha> --app <application>",
+		UsageText: "korn waitfor snapshot --sha <commit_sha> --app <application>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "application",
+				Aliases:     []string{"app"},
+				Usage:       "Example: -application my-application",
+				Required:    true,
+				Destination: &korn.ApplicationName,
+			},
+			&cli.StringFlag{
+				Name:        "sha",
+				Usage:       "Example: -sha 245fca6109a1f32e5ded0f7e330a85401aa2704a",
+				Required:    true,
+				Destination: &korn.SHA,
+			},
+			&cli.IntFlag{
+				Name:        "timeout",
+				Aliases:     []string{"t"},
+				Usage:       "-timeout timeout in minutes",
+				DefaultText: "Time out in minutes for the wait for operation to complete",
+				Value:       60,
+				Destination: &korn.WaitForTimeout,
+			},
+			&cli.BoolFlag{
+				Name:        "wait-for-retest",
+				Usage:       "Example: -wait-for-retest",
+				DefaultText: "Keeps waiting after the integration tests of a snapshot built from the commit fail, until the snapshot is retested or the commit rebuilt successfully, or the timeout is reached",
+				Destination: &korn.WaitForRetest,
+			},
+		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			korn.Namespace = ctx.Value(internal.NamespaceCtxType).(string)
+			korn.KubeClient = ctx.Value(internal.KubeCliCtxType).(client.Client)
+			korn.DynamicClient = ctx.Value(internal.DynamicCliCtxType).(dynamic.Interface)
+			return ctx, nil
+		},
+		Description: "Waits for the push event snapshot built from a commit to be created and to finish its integration tests, then prints its name. Fails if the tests fail or the timeout is reached, or only when the timeout is reached with -wait-for-retest. Timeout occurs after 60 minutes",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			s, err := korn.WaitForSnapshot(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Println(s.Name)
+			return nil
+		},
+	}
+}
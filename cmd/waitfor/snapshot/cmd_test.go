@@ -0,0 +1,101 @@
+package snapshot_test
+
+import (
+	"context"
+
+	"github.com/jordigilh/korn/cmd/waitfor/snapshot"
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	dfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testSHA = "abc123def456"
+
+var _ = Describe("Waitfor Snapshot Command", func() {
+	var (
+		fakeClientBuilder *fake.ClientBuilder
+		ctx               context.Context
+		cmd               *cli.Command
+		fw                *watch.FakeWatcher
+	)
+
+	BeforeEach(func() {
+		fakeClientBuilder = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(newNamespace(testutils.TestNamespace))
+		dynamicClient := dfake.NewSimpleDynamicClient(runtime.NewScheme())
+		fw = watch.NewFake()
+		dynamicClient.PrependWatchReactor("snapshots", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			return true, fw, nil
+		})
+		ctx = context.WithValue(context.Background(), internal.NamespaceCtxType, testutils.TestNamespace)
+		ctx = context.WithValue(ctx, internal.DynamicCliCtxType, dynamicClient)
+
+		cmd = snapshot.WaitForCommand()
+	})
+
+	It("should return when the existing snapshot has finished its tests", func() {
+		ctx = context.WithValue(ctx, internal.KubeCliCtxType, fakeClientBuilder.WithRuntimeObjects(testutils.NewTestSnapshot()).Build())
+
+		err := cmd.Run(ctx, []string{"", "--app", testutils.TestAppName, "--sha", testSHA})
+
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should wait for the snapshot to be created", func() {
+		ctx = context.WithValue(ctx, internal.KubeCliCtxType, fakeClientBuilder.Build())
+		go func() {
+			defer GinkgoRecover()
+			m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(testutils.NewTestSnapshot())
+			Expect(err).ToNot(HaveOccurred())
+			fw.Add(&unstructured.Unstructured{Object: m})
+		}()
+
+		err := cmd.Run(ctx, []string{"", "--app", testutils.TestAppName, "--sha", testSHA})
+
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fail when the tests of the snapshot failed", func() {
+		s := testutils.NewTestSnapshot()
+		s.Status.Conditions[0].Reason = "Failed"
+		s.Status.Conditions[0].Status = metav1.ConditionFalse
+		ctx = context.WithValue(ctx, internal.KubeCliCtxType, fakeClientBuilder.WithRuntimeObjects(s).Build())
+
+		err := cmd.Run(ctx, []string{"", "--app", testutils.TestAppName, "--sha", testSHA})
+
+		Expect(err).To(MatchError(ContainSubstring("integration tests of snapshot")))
+	})
+
+	It("should wait for a retest until the timeout when the tests of the snapshot failed", func() {
+		s := testutils.NewTestSnapshot()
+		s.Status.Conditions[0].Reason = "Failed"
+		s.Status.Conditions[0].Status = metav1.ConditionFalse
+		ctx = context.WithValue(ctx, internal.KubeCliCtxType, fakeClientBuilder.WithRuntimeObjects(s).Build())
+
+		err := cmd.Run(ctx, []string{"", "--app", testutils.TestAppName, "--sha", testSHA, "--wait-for-retest", "--timeout", "0"})
+
+		Expect(err).To(MatchError(ContainSubstring("timeout of 0 minute(s) reached")))
+	})
+
+	It("should require the sha flag", func() {
+		ctx = context.WithValue(ctx, internal.KubeCliCtxType, fakeClientBuilder.Build())
+
+		err := cmd.Run(ctx, []string{"", "--app", testutils.TestAppName})
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func newNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ptypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// GetDefaultImageCacheDir returns the directory where the inspected image metadata is cached by default
+func GetDefaultImageCacheDir() string {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "korn", "images")
+	}
+	return filepath.Join(os.TempDir(), "korn", "images")
+}
+
+// NewCachedImageClient wraps an image client with an on-disk cache of the inspected image metadata stored in dir.
+// Entries are indexed by digest and never expire, since the content of an image referenced by digest can't change.
+// Images referenced by tag are always inspected.
+func NewCachedImageClient(c ImageClient, dir string) ImageClient {
+	return cachedImageClient{client: c, dir: dir}
+}
+
+type cachedImageClient struct {
+	client ImageClient
+	dir    string
+}
+
+func (c cachedImageClient) GetImageData(imagePullSpec string) (*ptypes.ImageInspectReport, error) {
+	d, err := digest.Parse(ImageDigest(imagePullSpec))
+	if err != nil {
+		logrus.Debugf("image %s is not referenced by digest, skipping cache", imagePullSpec)
+		return c.client.GetImageData(imagePullSpec)
+	}
+	path := filepath.Join(c.dir, d.Algorithm().String(), d.Encoded()+".json")
+	data, err := readCachedImageData(path)
+	if err == nil {
+		logrus.Debugf("using cached image data for %s from %s", imagePullSpec, path)
+		return data, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		logrus.Debugf("ignoring invalid cache entry %s: %v", path, err)
+	}
+	data, err = c.client.GetImageData(imagePullSpec)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeCachedImageData(path, data); err != nil {
+		logrus.Warnf("unable to cache image data for %s: %v", imagePullSpec, err)
+	}
+	return data, nil
+}
+
+func readCachedImageData(path string) (*ptypes.ImageInspectReport, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data := ptypes.ImageInspectReport{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	if data.ImageData == nil {
+		return nil, errors.New("no image data found")
+	}
+	return &data, nil
+}
+
+func writeCachedImageData(path string, data *ptypes.ImageInspectReport) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that concurrent readers never see a partial entry
+	f, err := os.CreateTemp(filepath.Dir(path), strings.TrimSuffix(filepath.Base(path), ".json")+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
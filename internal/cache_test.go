@@ -0,0 +1,125 @@
+package internal_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/containers/podman/v5/pkg/inspect"
+	"github.com/jordigilh/korn/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	digestPullSpec = "registry.test.com/bundle@sha256:3f5c1b6c9a4f0e2d7b8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f"
+	tagPullSpec    = "registry.test.com/bundle:latest"
+)
+
+var _ = Describe("Cached image client", func() {
+	var (
+		dir    string
+		client *countingImageClient
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "korn-cache-test")
+		Expect(err).ToNot(HaveOccurred())
+		client = &countingImageClient{inspections: map[string]int{}}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should reuse the cached image data for images referenced by digest", func() {
+		for range 3 {
+			data, err := internal.NewCachedImageClient(client, dir).GetImageData(digestPullSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data.Labels).To(HaveKeyWithValue("version", "1.0.0"))
+		}
+		Expect(client.inspections[digestPullSpec]).To(Equal(1))
+		Expect(filepath.Join(dir, "sha256", "3f5c1b6c9a4f0e2d7b8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f.json")).To(BeARegularFile())
+	})
+
+	It("should always inspect images referenced by tag", func() {
+		for range 2 {
+			_, err := internal.NewCachedImageClient(client, dir).GetImageData(tagPullSpec)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(client.inspections[tagPullSpec]).To(Equal(2))
+	})
+
+	It("should inspect the image again when the cache entry is invalid", func() {
+		path := filepath.Join(dir, "sha256", "3f5c1b6c9a4f0e2d7b8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f.json")
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("{invalid"), 0o644)).To(Succeed())
+
+		data, err := internal.NewCachedImageClient(client, dir).GetImageData(digestPullSpec)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data.Labels).To(HaveKeyWithValue("version", "1.0.0"))
+		Expect(client.inspections[digestPullSpec]).To(Equal(1))
+	})
+
+	It("should not cache images that fail to be inspected", func() {
+		client.err = fmt.Errorf("image not found")
+
+		_, err := internal.NewCachedImageClient(client, dir).GetImageData(digestPullSpec)
+
+		Expect(err).To(HaveOccurred())
+		Expect(filepath.Join(dir, "sha256")).ToNot(BeADirectory())
+	})
+})
+
+var _ = Describe("Memoized image client", func() {
+	It("should inspect each digest only once when requested concurrently", func() {
+		client := &countingImageClient{inspections: map[string]int{}}
+		memoized := internal.NewMemoizedImageClient(client)
+		Expect(internal.NewMemoizedImageClient(memoized)).To(BeIdenticalTo(memoized))
+
+		wg := sync.WaitGroup{}
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				_, err := memoized.GetImageData(digestPullSpec)
+				Expect(err).ToNot(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+		Expect(client.inspections[digestPullSpec]).To(Equal(1))
+	})
+
+	DescribeTable("should extract the digest of an image pull spec",
+		func(pullSpec, expected string) {
+			Expect(internal.ImageDigest(pullSpec)).To(Equal(expected))
+		},
+		Entry("with a digest", "registry.test.com/bundle@sha256:abc123", "sha256:abc123"),
+		Entry("with a tag", tagPullSpec, ""),
+	)
+})
+
+type countingImageClient struct {
+	mu          sync.Mutex
+	inspections map[string]int
+	err         error
+}
+
+func (c *countingImageClient) GetImageData(image string) (*types.ImageInspectReport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inspections[image]++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &types.ImageInspectReport{
+		ImageData: &inspect.ImageData{
+			Labels: map[string]string{"version": "1.0.0"},
+		},
+	}, nil
+}
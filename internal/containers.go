@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/containers/podman/v5/pkg/bindings"
 	"github.com/containers/podman/v5/pkg/bindings/images"
@@ -51,3 +53,52 @@ func NewPodmanClient() (ImageClient, error) {
 type ImageClient interface {
 	GetImageData(imagePullSpec string) (*ptypes.ImageInspectReport, error)
 }
+
+// ImageDigest returns the digest of an image pull spec pinned by digest (e.g. sha256:abc...), or an empty string when
+// the pull spec references a tag
+func ImageDigest(imagePullSpec string) string {
+	i := strings.LastIndex(imagePullSpec, "@")
+	if i < 0 {
+		return ""
+	}
+	return imagePullSpec[i+1:]
+}
+
+// NewMemoizedImageClient wraps an image client so that images referenced by digest are only inspected once, even when
+// requested concurrently. Images referenced by tag are always inspected.
+func NewMemoizedImageClient(c ImageClient) ImageClient {
+	if _, ok := c.(*memoizedImageClient); ok {
+		return c
+	}
+	return &memoizedImageClient{client: c, images: map[string]*memoizedImage{}}
+}
+
+type memoizedImageClient struct {
+	client ImageClient
+	mu     sync.Mutex
+	images map[string]*memoizedImage
+}
+
+type memoizedImage struct {
+	once sync.Once
+	data *ptypes.ImageInspectReport
+	err  error
+}
+
+func (m *memoizedImageClient) GetImageData(imagePullSpec string) (*ptypes.ImageInspectReport, error) {
+	digest := ImageDigest(imagePullSpec)
+	if len(digest) == 0 {
+		return m.client.GetImageData(imagePullSpec)
+	}
+	m.mu.Lock()
+	img, ok := m.images[digest]
+	if !ok {
+		img = &memoizedImage{}
+		m.images[digest] = img
+	}
+	m.mu.Unlock()
+	img.once.Do(func() {
+		img.data, img.err = m.client.GetImageData(imagePullSpec)
+	})
+	return img.data, img.err
+}
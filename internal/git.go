@@ -1,13 +1,17 @@
 package internal
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/blang/semver/v4"
 	git "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,15 +19,14 @@ const (
 	versionFilePath = "VERSION.txt"
 )
 
+// ErrVersionFileNotFound is returned when the repository does not contain a VERSION.txt file at the given commit
+var ErrVersionFileNotFound = errors.New("version file not found")
+
 func (g *GitClient) GetVersion(repoURL, commitHash string) (*semver.Version, error) {
-	err := g.cloneRepository(repoURL)
-	if err != nil {
-		return nil, err
-	}
-	// Resolve the commit hash
-	hash := plumbing.NewHash(commitHash)
-	repo := normalizeRepoURL(repoURL)
-	commit, err := g.refs[repo].repo.CommitObject(hash)
+	// Repositories are cloned and read sequentially since go-git repositories are not safe for concurrent use
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	commit, err := g.getCommit(repoURL, commitHash)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +39,9 @@ func (g *GitClient) GetVersion(repoURL, commitHash string) (*semver.Version, err
 	// Find the file
 	file, err := tree.File(versionFilePath)
 	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, fmt.Errorf("%w: %s in %s at %s", ErrVersionFileNotFound, versionFilePath, repoURL, commitHash)
+		}
 		return nil, err
 	}
 	// Read the contents
@@ -58,6 +64,29 @@ func (g *GitClient) GetVersion(repoURL, commitHash string) (*semver.Version, err
 
 }
 
+// GetCommitTitle returns the first line of the message of the commit
+func (g *GitClient) GetCommitTitle(repoURL, commitHash string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	commit, err := g.getCommit(repoURL, commitHash)
+	if err != nil {
+		return "", err
+	}
+	title, _, _ := strings.Cut(commit.Message, "\n")
+	return strings.TrimSpace(title), nil
+}
+
+func (g *GitClient) getCommit(repoURL, commitHash string) (*object.Commit, error) {
+	err := g.cloneRepository(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve the commit hash
+	hash := plumbing.NewHash(commitHash)
+	repo := normalizeRepoURL(repoURL)
+	return g.refs[repo].repo.CommitObject(hash)
+}
+
 func normalizeRepoURL(repoURL string) string {
 	if strings.HasSuffix(repoURL, ".git") {
 		return strings.TrimSuffix(repoURL, ".git")
@@ -78,6 +107,7 @@ func (g *GitClient) cloneRepository(repoURL string) error {
 	logrus.Debugf("Cloning repository %s", repoURL)
 	r, err := git.PlainClone(tmpDir, false, &git.CloneOptions{URL: repoURL, ReferenceName: plumbing.HEAD})
 	if err != nil {
+		os.RemoveAll(tmpDir)
 		return err
 	}
 	g.refs[repo] = repositoryReference{file: tmpDir, repo: r}
@@ -86,10 +116,12 @@ func (g *GitClient) cloneRepository(repoURL string) error {
 
 type GitCommitVersioner interface {
 	GetVersion(commitHash, filePath string) (*semver.Version, error)
+	GetCommitTitle(repoURL, commitHash string) (string, error)
 	Cleanup()
 }
 
 type GitClient struct {
+	mu   sync.Mutex
 	refs map[string]repositoryReference
 }
 
@@ -103,11 +135,17 @@ func NewGitClient() GitCommitVersioner {
 		refs: make(map[string]repositoryReference),
 	}
 }
+
+// Cleanup removes the directories of the repositories cloned so far. Repositories requested afterwards are cloned
+// again.
 func (g *GitClient) Cleanup() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	for k, v := range g.refs {
 		logrus.Debugf("Cleaning up git clone directory for repo %s on %s", k, v.file)
-		if err := os.RemoveAll(k); err != nil {
-			logrus.Error(os.RemoveAll(v.file))
+		if err := os.RemoveAll(v.file); err != nil {
+			logrus.Error(err)
 		}
+		delete(g.refs, k)
 	}
 }
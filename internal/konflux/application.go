@@ -3,21 +3,29 @@ package konflux
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/blang/semver/v4"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (k Korn) ListApplications() (*applicationapiv1alpha1.ApplicationList, error) {
+const (
+	// StreamLabel groups the applications that release the version streams of the same operator
+	StreamLabel = "korn.redhat.io/stream"
+	// StreamVersionLabel is the major and minor version released by the application, such as "1.1"
+	StreamVersionLabel = "korn.redhat.io/version"
+)
 
-	list := applicationapiv1alpha1.ApplicationList{}
-	err := k.KubeClient.List(context.TODO(), &list, &client.ListOptions{Namespace: k.Namespace})
+func (k Korn) ListApplications() (*applicationapiv1alpha1.ApplicationList, error) {
+	items, err := listAll(k,
+		func(l *applicationapiv1alpha1.ApplicationList) []applicationapiv1alpha1.Application { return l.Items }, nil)
 	if err != nil {
 		return nil, err
 	}
-	return &list, nil
+	return &applicationapiv1alpha1.ApplicationList{Items: items}, nil
 }
 
 func (k Korn) GetApplication() (*applicationapiv1alpha1.Application, error) {
@@ -46,3 +54,54 @@ func (k Korn) GetApplicationType() (string, error) {
 	}
 	return appType, nil
 }
+
+// GetApplicationForStream returns the application of the operator stream whose version label matches the major and
+// minor of the version requested. When no version is requested, it returns the application of the latest version.
+func (k Korn) GetApplicationForStream() (*applicationapiv1alpha1.Application, error) {
+	var requested *semver.Version
+	if len(k.Version) > 0 {
+		v, err := semver.ParseTolerant(k.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %s: %v", k.Version, err)
+		}
+		requested = &v
+	}
+	apps, err := listAll(k,
+		func(l *applicationapiv1alpha1.ApplicationList) []applicationapiv1alpha1.Application { return l.Items }, nil,
+		client.MatchingLabels{StreamLabel: k.Operator})
+	if err != nil {
+		return nil, err
+	}
+	var matches []applicationapiv1alpha1.Application
+	var latest semver.Version
+	versions := []string{}
+	for _, app := range apps {
+		label := app.Labels[StreamVersionLabel]
+		v, err := semver.ParseTolerant(label)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label %s=%s in application %s/%s: %v", StreamVersionLabel, label, app.Namespace, app.Name, err)
+		}
+		versions = append(versions, label)
+		switch {
+		case requested != nil && (v.Major != requested.Major || v.Minor != requested.Minor):
+		case requested == nil && len(matches) > 0 && v.LT(latest):
+		case requested == nil && len(matches) > 0 && v.GT(latest):
+			matches, latest = []applicationapiv1alpha1.Application{app}, v
+		default:
+			matches, latest = append(matches, app), v
+		}
+	}
+	switch {
+	case len(apps) == 0:
+		return nil, fmt.Errorf("no application found in namespace %s with label %s=%s", k.Namespace, StreamLabel, k.Operator)
+	case len(matches) == 0:
+		return nil, fmt.Errorf("no application found for operator %s with version %s: available versions are %s", k.Operator, k.Version, strings.Join(versions, ", "))
+	case len(matches) > 1:
+		names := []string{}
+		for _, app := range matches {
+			names = append(names, app.Name)
+		}
+		return nil, fmt.Errorf("multiple applications found for operator %s with version %s: %s", k.Operator, latest, strings.Join(names, ", "))
+	}
+	return &matches[0], nil
+}
@@ -2,6 +2,7 @@ package konflux_test
 
 import (
 	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -67,3 +68,59 @@ func newApp(name, namespace string) *applicationapiv1alpha1.Application {
 		},
 	}
 }
+
+var _ = Describe("Resolving the application of an operator version stream", func() {
+	var kornInstance konflux.Korn
+
+	BeforeEach(func() {
+		kornInstance = konflux.Korn{
+			Namespace: testutils.TestNamespace,
+			Operator:  "my-operator",
+			KubeClient: fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+				testutils.NewStreamApplication("operator-1-0", testutils.TestNamespace, "my-operator", "1.0"),
+				testutils.NewStreamApplication("operator-1-1", testutils.TestNamespace, "my-operator", "1.1"),
+				testutils.NewStreamApplication("operator-1-10", testutils.TestNamespace, "my-operator", "1.10"),
+				testutils.NewStreamApplication("other-2-0", testutils.TestNamespace, "other-operator", "2.0"),
+				testutils.NewOperatorApplication("unlabeled", testutils.TestNamespace),
+			).Build(),
+		}
+	})
+
+	DescribeTable("should resolve the application",
+		func(version string, expected string) {
+			kornInstance.Version = version
+
+			app, err := kornInstance.GetApplicationForStream()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(app.Name).To(Equal(expected))
+		},
+		Entry("matching the major and minor of the version", "1.1", "operator-1-1"),
+		Entry("matching a full version with prefix", "v1.1.3", "operator-1-1"),
+		Entry("of the latest version when no version is provided", "", "operator-1-10"),
+	)
+
+	DescribeTable("should fail",
+		func(operator, version string, expectedError string) {
+			kornInstance.Operator, kornInstance.Version = operator, version
+
+			_, err := kornInstance.GetApplicationForStream()
+
+			Expect(err).To(MatchError(ContainSubstring(expectedError)))
+		},
+		Entry("when the operator has no applications", "unknown", "", "no application found in namespace test-namespace with label korn.redhat.io/stream=unknown"),
+		Entry("when the version stream doesn't exist", "my-operator", "1.2", "no application found for operator my-operator with version 1.2: available versions are"),
+		Entry("with an invalid version", "my-operator", "latest", "invalid version latest"),
+	)
+
+	It("should fail when several applications release the same version", func() {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			testutils.NewStreamApplication("operator-1-1", testutils.TestNamespace, "my-operator", "1.1"),
+			testutils.NewStreamApplication("operator-1-1-hotfix", testutils.TestNamespace, "my-operator", "1.1"),
+		).Build()
+
+		_, err := kornInstance.GetApplicationForStream()
+
+		Expect(err).To(MatchError(ContainSubstring("multiple applications found for operator my-operator with version 1.1.0")))
+	})
+})
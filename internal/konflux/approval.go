@@ -0,0 +1,125 @@
+package konflux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ApprovalLabel stores whether a snapshot was manually approved or rejected for release
+	ApprovalLabel               = "korn.redhat.io/approval"
+	ApprovalReasonAnnotation    = "korn.redhat.io/approval-reason"
+	ApprovalTimestampAnnotation = "korn.redhat.io/approval-timestamp"
+	// RequireApprovalLabel marks the release plans whose environment only accepts manually approved snapshots
+	RequireApprovalLabel = "korn.redhat.io/require-approval"
+)
+
+// ApprovalState is the manual approval state of a snapshot
+type ApprovalState string
+
+const (
+	ApprovedState ApprovalState = "approved"
+	RejectedState ApprovalState = "rejected"
+)
+
+// GetSnapshotApproval returns the manual approval state of the snapshot, or an empty state if it has neither been
+// approved nor rejected
+func GetSnapshotApproval(snapshot applicationapiv1alpha1.Snapshot) ApprovalState {
+	switch s := ApprovalState(snapshot.Labels[ApprovalLabel]); s {
+	case ApprovedState, RejectedState:
+		return s
+	}
+	return ""
+}
+
+// SnapshotApprovalStatus returns the manual approval state of the snapshot as shown to users: Approved, Rejected or
+// an empty string
+func SnapshotApprovalStatus(snapshot applicationapiv1alpha1.Snapshot) string {
+	switch GetSnapshotApproval(snapshot) {
+	case ApprovedState:
+		return "Approved"
+	case RejectedState:
+		return "Rejected"
+	}
+	return ""
+}
+
+// SnapshotApprovalStatusDetails returns the manual approval state of the snapshot followed by the reason given, if any
+func SnapshotApprovalStatusDetails(snapshot applicationapiv1alpha1.Snapshot) string {
+	state := SnapshotApprovalStatus(snapshot)
+	if reason := snapshot.Annotations[ApprovalReasonAnnotation]; len(state) > 0 && len(reason) > 0 {
+		return fmt.Sprintf("%s (%s)", state, reason)
+	}
+	return state
+}
+
+// ApproveSnapshot marks the snapshot as approved for release, overriding any previous rejection
+func (k Korn) ApproveSnapshot() (*applicationapiv1alpha1.Snapshot, error) {
+	return k.setSnapshotApproval(ApprovedState)
+}
+
+// RejectSnapshot marks the snapshot as rejected so that it is never selected as a candidate for release
+func (k Korn) RejectSnapshot() (*applicationapiv1alpha1.Snapshot, error) {
+	if len(k.ApprovalReason) == 0 {
+		return nil, errors.New("a reason is required to reject a snapshot")
+	}
+	return k.setSnapshotApproval(RejectedState)
+}
+
+func (k Korn) setSnapshotApproval(state ApprovalState) (*applicationapiv1alpha1.Snapshot, error) {
+	if len(k.SnapshotName) == 0 {
+		return nil, errors.New("snapshot name is required")
+	}
+	snapshot, err := k.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	patch := client.MergeFrom(snapshot.DeepCopy())
+	if snapshot.Labels == nil {
+		snapshot.Labels = map[string]string{}
+	}
+	if snapshot.Annotations == nil {
+		snapshot.Annotations = map[string]string{}
+	}
+	snapshot.Labels[ApprovalLabel] = string(state)
+	snapshot.Annotations[ApprovalReasonAnnotation] = k.ApprovalReason
+	snapshot.Annotations[ApprovalTimestampAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := k.KubeClient.Patch(context.TODO(), snapshot, patch); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// approvalVerdict returns the verdict of a snapshot that was rejected, or that was not approved when k.RequireApproval
+// is set. The second value is false when the manual approval does not prevent the snapshot from being a candidate.
+func (k Korn) approvalVerdict(snapshot applicationapiv1alpha1.Snapshot) (Verdict, bool) {
+	switch GetSnapshotApproval(snapshot) {
+	case RejectedState:
+		return rejectSnapshot(ApprovalRule, "snapshot %s was rejected: %s", snapshot.Name, snapshot.Annotations[ApprovalReasonAnnotation]), true
+	case ApprovedState:
+		return Verdict{}, false
+	}
+	if k.RequireApproval {
+		return rejectSnapshot(ApprovalRule, "snapshot %s has not been approved", snapshot.Name), true
+	}
+	return Verdict{}, false
+}
+
+// checkSnapshotApproval returns an error when a snapshot chosen explicitly can't be released due to its approval state
+func (k Korn) checkSnapshotApproval(snapshot applicationapiv1alpha1.Snapshot) error {
+	if v, ok := k.approvalVerdict(snapshot); ok {
+		return errors.New(v.Message)
+	}
+	return nil
+}
+
+// requiresApproval returns true when the release plan's environment only accepts manually approved snapshots
+func requiresApproval(rp releaseapiv1alpha1.ReleasePlan) bool {
+	return rp.Labels[RequireApprovalLabel] == "true"
+}
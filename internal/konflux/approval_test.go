@@ -0,0 +1,188 @@
+package konflux_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	newerSnapshotName = "newer-snapshot"
+	olderSnapshotName = "older-snapshot"
+)
+
+var _ = Describe("Snapshot approval", func() {
+	var (
+		kornInstance *konflux.Korn
+		builder      *fake.ClientBuilder
+		newer, older *applicationapiv1alpha1.Snapshot
+	)
+
+	BeforeEach(func() {
+		newer = newFinishedSnapshot(newerSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		older = newFinishedSnapshot(olderSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		older.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		builder = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			newNamespace(testutils.TestNamespace),
+			testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName)
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			EnvironmentName: "staging",
+			PodClient:       &mockImageClientValid{},
+		}
+	})
+
+	setApproval := func(s *applicationapiv1alpha1.Snapshot, state konflux.ApprovalState) {
+		s.Labels[konflux.ApprovalLabel] = string(state)
+		s.Annotations = map[string]string{konflux.ApprovalReasonAnnotation: "manual review"}
+	}
+
+	Context("Approving and rejecting snapshots", func() {
+		getStoredSnapshot := func() applicationapiv1alpha1.Snapshot {
+			s := applicationapiv1alpha1.Snapshot{}
+			Expect(kornInstance.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testutils.TestNamespace, Name: newerSnapshotName}, &s)).To(Succeed())
+			return s
+		}
+
+		It("should label a rejected snapshot with the reason", func() {
+			kornInstance.KubeClient = builder.WithRuntimeObjects(newer).Build()
+			kornInstance.SnapshotName = newerSnapshotName
+			kornInstance.ApprovalReason = "fails upgrade tests"
+
+			_, err := kornInstance.RejectSnapshot()
+
+			Expect(err).ToNot(HaveOccurred())
+			s := getStoredSnapshot()
+			Expect(konflux.GetSnapshotApproval(s)).To(Equal(konflux.RejectedState))
+			Expect(s.Annotations).To(HaveKeyWithValue(konflux.ApprovalReasonAnnotation, "fails upgrade tests"))
+		})
+
+		It("should lift a rejection when approving the snapshot", func() {
+			setApproval(newer, konflux.RejectedState)
+			kornInstance.KubeClient = builder.WithRuntimeObjects(newer).Build()
+			kornInstance.SnapshotName = newerSnapshotName
+
+			_, err := kornInstance.ApproveSnapshot()
+
+			Expect(err).ToNot(HaveOccurred())
+			s := getStoredSnapshot()
+			Expect(konflux.GetSnapshotApproval(s)).To(Equal(konflux.ApprovedState))
+			Expect(s.Annotations).To(HaveKeyWithValue(konflux.ApprovalReasonAnnotation, ""))
+		})
+
+		It("should require a reason to reject a snapshot", func() {
+			kornInstance.KubeClient = builder.WithRuntimeObjects(newer).Build()
+			kornInstance.SnapshotName = newerSnapshotName
+
+			_, err := kornInstance.RejectSnapshot()
+
+			Expect(err).To(HaveOccurred())
+			Expect(konflux.GetSnapshotApproval(getStoredSnapshot())).To(BeEmpty())
+		})
+
+		It("should require the snapshot name", func() {
+			kornInstance.KubeClient = builder.WithRuntimeObjects(newer).Build()
+
+			_, err := kornInstance.ApproveSnapshot()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("snapshot name is required"))
+		})
+	})
+
+	Context("Candidate selection", func() {
+		DescribeTable("should take the approval state into account",
+			func(newerState, olderState konflux.ApprovalState, requireApproval bool, expected string) {
+				setApproval(newer, newerState)
+				setApproval(older, olderState)
+				kornInstance.KubeClient = builder.WithRuntimeObjects(newer, older).Build()
+				kornInstance.RequireApproval = requireApproval
+
+				candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+				if expected == "" {
+					Expect(err).To(HaveOccurred())
+					Expect(candidate).To(BeNil())
+					return
+				}
+				Expect(err).ToNot(HaveOccurred())
+				Expect(candidate.Name).To(Equal(expected))
+			},
+			Entry("without manual approvals", konflux.ApprovalState(""), konflux.ApprovalState(""), false, newerSnapshotName),
+			Entry("skipping a rejected snapshot", konflux.RejectedState, konflux.ApprovalState(""), false, olderSnapshotName),
+			Entry("with all snapshots rejected", konflux.RejectedState, konflux.RejectedState, false, ""),
+			Entry("requiring approval", konflux.ApprovalState(""), konflux.ApprovedState, true, olderSnapshotName),
+			Entry("requiring approval without approved snapshots", konflux.ApprovalState(""), konflux.ApprovalState(""), true, ""),
+			Entry("requiring approval with a rejected snapshot", konflux.RejectedState, konflux.ApprovalState(""), true, ""),
+		)
+
+		It("should not release a rejected snapshot chosen explicitly", func() {
+			setApproval(newer, konflux.RejectedState)
+			kornInstance.KubeClient = builder.WithRuntimeObjects(newer).Build()
+			kornInstance.SnapshotName = newerSnapshotName
+
+			candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("was rejected: manual review"))
+			Expect(candidate).To(BeNil())
+		})
+
+		It("should not record the verdict of rejected snapshots", func() {
+			setApproval(newer, konflux.RejectedState)
+			kornInstance.KubeClient = builder.WithRuntimeObjects(newer, older).Build()
+			kornInstance.RecordVerdicts = true
+
+			_, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).ToNot(HaveOccurred())
+			s := applicationapiv1alpha1.Snapshot{}
+			Expect(kornInstance.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testutils.TestNamespace, Name: newerSnapshotName}, &s)).To(Succeed())
+			Expect(s.Annotations).ToNot(HaveKey(konflux.VerdictAnnotation))
+		})
+	})
+
+	Context("Gated environments", func() {
+		DescribeTable("should require approval when the release plan is labeled",
+			func(labelValue string, expected string) {
+				rp := testutils.NewStagingReleasePlan(testutils.TestReleasePlan, testutils.TestNamespace, testutils.TestAppName)
+				rp.Labels[konflux.RequireApprovalLabel] = labelValue
+				setApproval(older, konflux.ApprovedState)
+				kornInstance.KubeClient = builder.WithRuntimeObjects(newer, older, rp).Build()
+
+				release, err := kornInstance.GenerateReleaseManifest()
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(release.Spec.Snapshot).To(Equal(expected))
+			},
+			Entry("with approval required", "true", olderSnapshotName),
+			Entry("with approval not required", "false", newerSnapshotName),
+		)
+	})
+
+	DescribeTable("should show the approval status of the snapshot",
+		func(state konflux.ApprovalState, expected, expectedDetails string) {
+			if len(state) > 0 {
+				setApproval(newer, state)
+			}
+
+			Expect(konflux.SnapshotApprovalStatus(*newer)).To(Equal(expected))
+			Expect(konflux.SnapshotApprovalStatusDetails(*newer)).To(Equal(expectedDetails))
+		},
+		Entry("when approved", konflux.ApprovedState, "Approved", "Approved (manual review)"),
+		Entry("when rejected", konflux.RejectedState, "Rejected", "Rejected (manual review)"),
+		Entry("without manual approval", konflux.ApprovalState(""), "", ""),
+	)
+})
@@ -0,0 +1,251 @@
+package konflux
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	ptypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// ApplicationTypeHandler implements the steps of a release that depend on the type of the application, which is set
+// with the ApplicationTypeLabel
+type ApplicationTypeHandler interface {
+	// ReleaseComponents returns the components whose builds produce the snapshots to release
+	ReleaseComponents(k Korn) ([]applicationapiv1alpha1.Component, error)
+	// SnapshotValidator returns the function that validates the images of the snapshot candidates. The resources the
+	// validation depends on are read once, so the function can be called for any number of snapshots.
+	SnapshotValidator(k Korn) (SnapshotValidator, error)
+	// ReleaseNotes returns the release notes of the release of the snapshot
+	ReleaseNotes(k Korn, snapshot applicationapiv1alpha1.Snapshot) (ReleaseNote, error)
+}
+
+// SnapshotValidator validates the images of a snapshot whose tests have succeeded. Snapshots that are not candidates
+// for release are rejected with the rule they failed.
+type SnapshotValidator func(snapshot applicationapiv1alpha1.Snapshot) (Verdict, error)
+
+// applicationTypes contains the handler of each application type, indexed by the value of the ApplicationTypeLabel
+var applicationTypes = map[string]ApplicationTypeHandler{
+	operatorApplicationType:       operatorHandler{},
+	fbcApplicationType:            fbcHandler{},
+	containerImageApplicationType: containerImageHandler{},
+	helmApplicationType:           helmHandler{},
+}
+
+// RegisterApplicationType adds the handler of a new application type, or replaces the one of an existing type. It is
+// not safe for concurrent use, so handlers must be registered before running any command.
+func RegisterApplicationType(name string, handler ApplicationTypeHandler) {
+	applicationTypes[name] = handler
+}
+
+// ApplicationTypes returns the sorted names of the application types with a handler
+func ApplicationTypes() []string {
+	return slices.Sorted(maps.Keys(applicationTypes))
+}
+
+func (k Korn) getApplicationTypeHandler() (ApplicationTypeHandler, error) {
+	appType, err := k.GetApplicationType()
+	if err != nil {
+		return nil, err
+	}
+	handler, ok := applicationTypes[appType]
+	if !ok {
+		return nil, fmt.Errorf("undefined application type %s for application %s/%s, supported types are %s", appType, k.Namespace, k.ApplicationName, strings.Join(ApplicationTypes(), ", "))
+	}
+	return handler, nil
+}
+
+// getSnapshotValidator returns the validator of the snapshots of the application, based on its type
+func (k Korn) getSnapshotValidator() (SnapshotValidator, error) {
+	handler, err := k.getApplicationTypeHandler()
+	if err != nil {
+		return nil, err
+	}
+	return handler.SnapshotValidator(k)
+}
+
+// operatorHandler releases the bundles of one or more operators together with the components they reference
+type operatorHandler struct{}
+
+func (operatorHandler) ReleaseComponents(k Korn) ([]applicationapiv1alpha1.Component, error) {
+	return k.GetBundleComponents()
+}
+
+func (operatorHandler) SnapshotValidator(k Korn) (SnapshotValidator, error) {
+	refs, err := k.getBundleReferences()
+	if err != nil {
+		return nil, err
+	}
+	return func(snapshot applicationapiv1alpha1.Snapshot) (Verdict, error) {
+		return k.validateSnapshotImages(refs, snapshot)
+	}, nil
+}
+
+// ReleaseNotes returns the release notes provided by the user or, when there are none, a bug fix release when the
+// version of any of the bundles is a patch
+func (operatorHandler) ReleaseNotes(k Korn, snapshot applicationapiv1alpha1.Snapshot) (ReleaseNote, error) {
+	if k.ReleaseNotes != nil {
+		return *k.ReleaseNotes, nil
+	}
+	bundleVersions, err := k.getBundleVersionsFromSnapshot(snapshot)
+	if err != nil {
+		return ReleaseNote{}, err
+	}
+	return releaseNotesForVersions(bundleVersions)
+}
+
+// fbcHandler releases the only component of a file based catalog application
+type fbcHandler struct{}
+
+// ReleaseComponents returns the only component of the application, as Konflux recommends for file based catalogs
+func (fbcHandler) ReleaseComponents(k Korn) ([]applicationapiv1alpha1.Component, error) {
+	comps, err := k.ListComponents()
+	if err != nil {
+		return nil, err
+	}
+	if len(comps) == 0 {
+		return nil, fmt.Errorf("application %s/%s does not have any component associated", k.Namespace, k.ApplicationName)
+	}
+	if len(comps) > 1 {
+		return nil, fmt.Errorf("application %s/%s of type FBC can only have 1 component per Konflux recommendation ", k.Namespace, k.ApplicationName)
+	}
+	return comps, nil
+}
+
+func (h fbcHandler) SnapshotValidator(k Korn) (SnapshotValidator, error) {
+	comps, err := h.ReleaseComponents(k)
+	if err != nil {
+		return nil, err
+	}
+	return func(snapshot applicationapiv1alpha1.Snapshot) (Verdict, error) {
+		return k.validateComponentImages(comps, snapshot)
+	}, nil
+}
+
+// ReleaseNotes always returns a feature release, since catalogs don't have a version of their own
+func (fbcHandler) ReleaseNotes(Korn, applicationapiv1alpha1.Snapshot) (ReleaseNote, error) {
+	return ReleaseNote{Type: featureReleaseType}, nil
+}
+
+// containerImageHandler releases all the container images of the application, with no bundle referencing them
+type containerImageHandler struct{}
+
+func (containerImageHandler) ReleaseComponents(k Korn) ([]applicationapiv1alpha1.Component, error) {
+	return k.listReleaseComponents()
+}
+
+func (containerImageHandler) SnapshotValidator(k Korn) (SnapshotValidator, error) {
+	comps, err := k.listReleaseComponents()
+	if err != nil {
+		return nil, err
+	}
+	return func(snapshot applicationapiv1alpha1.Snapshot) (Verdict, error) {
+		return k.validateComponentImages(comps, snapshot)
+	}, nil
+}
+
+// ReleaseNotes returns the release notes provided by the user or, when there are none, a bug fix release when the
+// version label of any of the images is a patch. Images without the version label are ignored.
+func (containerImageHandler) ReleaseNotes(k Korn, snapshot applicationapiv1alpha1.Snapshot) (ReleaseNote, error) {
+	if k.ReleaseNotes != nil {
+		return *k.ReleaseNotes, nil
+	}
+	versions := []string{}
+	for _, c := range snapshot.Spec.Components {
+		data, err := k.PodClient.GetImageData(c.ContainerImage)
+		if err != nil {
+			return ReleaseNote{}, err
+		}
+		if v, ok := data.Labels[versionImageLabel]; ok {
+			versions = append(versions, v)
+		}
+	}
+	return releaseNotesForVersions(versions)
+}
+
+// helmHandler releases the Helm charts of the application. Charts are pushed as OCI artifacts that are not container
+// images, so their labels can't be inspected.
+type helmHandler struct{}
+
+func (helmHandler) ReleaseComponents(k Korn) ([]applicationapiv1alpha1.Component, error) {
+	return k.listReleaseComponents()
+}
+
+// SnapshotValidator verifies that the snapshot contains the chart of every component, pinned by digest
+func (helmHandler) SnapshotValidator(k Korn) (SnapshotValidator, error) {
+	comps, err := k.listReleaseComponents()
+	if err != nil {
+		return nil, err
+	}
+	return func(snapshot applicationapiv1alpha1.Snapshot) (Verdict, error) {
+		for _, c := range comps {
+			spec, err := GetComponentPullspecFromSnapshot(snapshot, c.Name)
+			if err != nil {
+				return Verdict{}, err
+			}
+			if !strings.Contains(spec, "@sha256:") {
+				return rejectSnapshot(ChartReferenceRule, "chart %s of component %s in snapshot %s is not pinned by digest", spec, c.Name, snapshot.Name), nil
+			}
+		}
+		return Verdict{Valid: true}, nil
+	}, nil
+}
+
+// ReleaseNotes returns the release notes provided by the user or a feature release otherwise
+func (helmHandler) ReleaseNotes(k Korn, _ applicationapiv1alpha1.Snapshot) (ReleaseNote, error) {
+	if k.ReleaseNotes != nil {
+		return *k.ReleaseNotes, nil
+	}
+	return ReleaseNote{Type: featureReleaseType}, nil
+}
+
+// listReleaseComponents returns all the components of the application, which must have at least one
+func (k Korn) listReleaseComponents() ([]applicationapiv1alpha1.Component, error) {
+	comps, err := k.ListComponents()
+	if err != nil {
+		return nil, err
+	}
+	if len(comps) == 0 {
+		return nil, fmt.Errorf("application %s/%s does not have any component associated", k.Namespace, k.ApplicationName)
+	}
+	return comps, nil
+}
+
+// validateComponentImages inspects the image of each component in the snapshot and validates it against the version
+// of the component's sources
+func (k Korn) validateComponentImages(comps []applicationapiv1alpha1.Component, snapshot applicationapiv1alpha1.Snapshot) (Verdict, error) {
+	images := map[string]*ptypes.ImageInspectReport{}
+	for _, c := range comps {
+		spec, err := GetComponentPullspecFromSnapshot(snapshot, c.Name)
+		if err != nil {
+			return Verdict{}, err
+		}
+		data, err := k.PodClient.GetImageData(spec)
+		if err != nil {
+			return Verdict{}, err
+		}
+		images[c.Name] = data
+	}
+	return k.validateSourceVersions(snapshot, images)
+}
+
+// releaseNotesForVersions returns a bug fix release as soon as one of the versions is a patch, and a feature release
+// otherwise
+func releaseNotesForVersions(versions []string) (ReleaseNote, error) {
+	rtype := featureReleaseType
+	for _, version := range versions {
+		semv, err := semver.ParseTolerant(version)
+		if err != nil {
+			return ReleaseNote{}, err
+		}
+		if semv.Patch != 0 {
+			logrus.Debugf("version %s is a patch, releasing as %s", version, bugReleaseType)
+			rtype = bugReleaseType
+		}
+	}
+	return ReleaseNote{Type: rtype}, nil
+}
@@ -0,0 +1,118 @@
+package konflux_test
+
+import (
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// customHandler releases every snapshot of the application as a security release
+type customHandler struct{}
+
+func (customHandler) ReleaseComponents(k konflux.Korn) ([]applicationapiv1alpha1.Component, error) {
+	return k.ListComponents()
+}
+
+func (customHandler) SnapshotValidator(konflux.Korn) (konflux.SnapshotValidator, error) {
+	return func(applicationapiv1alpha1.Snapshot) (konflux.Verdict, error) {
+		return konflux.Verdict{Valid: true}, nil
+	}, nil
+}
+
+func (customHandler) ReleaseNotes(konflux.Korn, applicationapiv1alpha1.Snapshot) (konflux.ReleaseNote, error) {
+	return konflux.ReleaseNote{Type: "RHSA"}, nil
+}
+
+var _ = Describe("Application types", func() {
+	const apiComponentName = "api"
+	var kornInstance *konflux.Korn
+
+	buildClient := func(appType string, objs ...runtime.Object) {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			append([]runtime.Object{
+				newNamespace(testutils.TestNamespace),
+				testutils.NewApplication(testutils.TestAppName, testutils.TestNamespace, map[string]string{konflux.ApplicationTypeLabel: appType}),
+				testutils.NewComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+				testutils.NewComponent(apiComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+				testutils.NewStagingReleasePlan("staging-rp", testutils.TestNamespace, testutils.TestAppName),
+			}, objs...)...,
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName).Build()
+	}
+
+	BeforeEach(func() {
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			EnvironmentName: "staging",
+		}
+	})
+
+	Context("container-image applications", func() {
+		DescribeTable("should release all the images of the snapshot",
+			func(version string, notes *konflux.ReleaseNote, expected string) {
+				buildClient("container-image", newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, apiComponentName))
+				kornInstance.PodClient = &mockImageClientWithVersion{version: version}
+				kornInstance.ReleaseNotes = notes
+
+				release, err := kornInstance.GenerateReleaseManifest()
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(release.Spec.Snapshot).To(Equal(testutils.TestSnapshotName))
+				Expect(release.Spec.ReleasePlan).To(Equal("staging-rp"))
+				Expect(string(release.Spec.Data.Raw)).To(ContainSubstring(expected))
+			},
+			Entry("as a feature release for a minor version", "1.2.0", nil, "RHEA"),
+			Entry("as a bug fix release for a patch version", "1.2.1", nil, "RHBA"),
+			Entry("with the release notes provided", "1.2.0", &konflux.ReleaseNote{Type: "RHSA"}, "RHSA"),
+		)
+	})
+
+	Context("helm applications", func() {
+		It("should release the charts without inspecting them", func() {
+			buildClient("helm", newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, apiComponentName))
+
+			release, err := kornInstance.GenerateReleaseManifest()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(release.Spec.Snapshot).To(Equal(testutils.TestSnapshotName))
+			Expect(string(release.Spec.Data.Raw)).To(ContainSubstring("RHEA"))
+		})
+
+		It("should reject the snapshots with charts not pinned by digest", func() {
+			snapshot := newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, apiComponentName)
+			snapshot.Spec.Components[1].ContainerImage = "registry.test.com/charts/api:1.0.0"
+			buildClient("helm", snapshot)
+
+			_, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no new valid snapshot candidates found for application test-namespace/test-app"))
+		})
+	})
+
+	It("should fail with the supported types when the application type has no handler", func() {
+		buildClient("wasm")
+
+		_, err := kornInstance.GenerateReleaseManifest()
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("undefined application type wasm for application test-namespace/test-app"))
+		Expect(err.Error()).To(ContainSubstring("fbc, helm, operator"))
+	})
+
+	It("should use the handlers registered for new application types", func() {
+		konflux.RegisterApplicationType("custom", customHandler{})
+		Expect(konflux.ApplicationTypes()).To(ContainElement("custom"))
+		buildClient("custom", newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, apiComponentName))
+
+		release, err := kornInstance.GenerateReleaseManifest()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(release.Spec.Snapshot).To(Equal(testutils.TestSnapshotName))
+		Expect(string(release.Spec.Data.Raw)).To(ContainSubstring("RHSA"))
+	})
+})
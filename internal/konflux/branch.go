@@ -0,0 +1,60 @@
+package konflux
+
+import (
+	"strings"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// BranchAnnotation defines in the application the git branch its release candidates must be built from when no
+	// branch is given in the command line
+	BranchAnnotation = "korn.redhat.io/branch"
+
+	// targetBranchKey and sourceBranchKey are copied by the integration service from the Pipelines as Code metadata of
+	// the build PipelineRun
+	targetBranchKey = "pac.test.appstudio.openshift.io/branch"
+	sourceBranchKey = "pac.test.appstudio.openshift.io/source-branch"
+)
+
+// GetSnapshotBranch returns the git branch the snapshot was built from, based on the Pipelines as Code metadata of
+// the push event that triggered it. It returns an empty string when the snapshot does not contain that metadata.
+func GetSnapshotBranch(snapshot applicationapiv1alpha1.Snapshot) string {
+	for _, key := range []string{targetBranchKey, sourceBranchKey} {
+		if b, ok := snapshot.Annotations[key]; ok && len(b) > 0 {
+			return strings.TrimPrefix(b, "refs/heads/")
+		}
+		if b, ok := snapshot.Labels[key]; ok && len(b) > 0 {
+			return strings.TrimPrefix(b, "refs/heads/")
+		}
+	}
+	return ""
+}
+
+// getReleaseBranch returns the branch the release candidates must be built from: k.Branch when set, otherwise the
+// one defined in the application with the BranchAnnotation. An empty string means snapshots from any branch are
+// accepted.
+func (k Korn) getReleaseBranch() (string, error) {
+	if len(k.Branch) > 0 || len(k.ApplicationName) == 0 {
+		return k.Branch, nil
+	}
+	app, err := k.GetApplication()
+	if err != nil {
+		return "", err
+	}
+	return app.Annotations[BranchAnnotation], nil
+}
+
+// isSnapshotFromBranch returns whether the snapshot was built from the branch. Snapshots without branch metadata are
+// discarded since their origin can't be determined. All snapshots are accepted when the branch is empty.
+func isSnapshotFromBranch(snapshot applicationapiv1alpha1.Snapshot, branch string) bool {
+	if len(branch) == 0 {
+		return true
+	}
+	if b := GetSnapshotBranch(snapshot); b != branch {
+		logrus.Debugf("snapshot %s/%s built from branch %q does not match branch %s", snapshot.Namespace, snapshot.Name, b, branch)
+		return false
+	}
+	return true
+}
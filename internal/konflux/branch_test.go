@@ -0,0 +1,126 @@
+package konflux_test
+
+import (
+	"time"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Branch aware candidate selection", func() {
+	const (
+		mainSnapshotName    = "main-snapshot"
+		hotfixSnapshotName  = "hotfix-snapshot"
+		releasedZStreamName = "released-zstream-snapshot"
+		zStreamBranch       = "release-1.0"
+	)
+
+	var (
+		kornInstance *konflux.Korn
+		app          *applicationapiv1alpha1.Application
+	)
+
+	newBranchSnapshot := func(name, branch string, age time.Duration) *applicationapiv1alpha1.Snapshot {
+		s := newFinishedSnapshot(name, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		s.Annotations = map[string]string{"pac.test.appstudio.openshift.io/branch": branch}
+		s.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		return s
+	}
+
+	BeforeEach(func() {
+		app = testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace)
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			PodClient:       &mockImageClientValid{},
+		}
+	})
+
+	build := func(objs ...runtime.Object) {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(objs...).WithRuntimeObjects(
+			newNamespace(testutils.TestNamespace),
+			app,
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+			newBranchSnapshot(mainSnapshotName, "main", 0),
+			newBranchSnapshot(hotfixSnapshotName, zStreamBranch, time.Hour),
+			newBranchSnapshot(releasedZStreamName, zStreamBranch, 2*time.Hour),
+			testutils.NewSuccessfulRelease("main-release", testutils.TestNamespace, mainSnapshotName, testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName),
+			testutils.NewSuccessfulRelease("zstream-release", testutils.TestNamespace, releasedZStreamName, testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName),
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName).Build()
+	}
+
+	DescribeTable("should read the branch from the Pipelines as Code metadata",
+		func(annotations, labels map[string]string, expected string) {
+			s := newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+			s.Annotations = annotations
+			for k, v := range labels {
+				s.Labels[k] = v
+			}
+			Expect(konflux.GetSnapshotBranch(*s)).To(Equal(expected))
+		},
+		Entry("from the target branch annotation", map[string]string{"pac.test.appstudio.openshift.io/branch": "main"}, nil, "main"),
+		Entry("from the source branch label", nil, map[string]string{"pac.test.appstudio.openshift.io/source-branch": "refs/heads/release-1.0"}, "release-1.0"),
+		Entry("without branch metadata", nil, nil, ""),
+	)
+
+	It("should only consider the snapshots of the given branch after its last release", func() {
+		kornInstance.Branch = zStreamBranch
+		build()
+
+		candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Name).To(Equal(hotfixSnapshotName))
+	})
+
+	It("should skip the releases whose snapshot no longer exists", func() {
+		kornInstance.Branch = zStreamBranch
+		pruned := testutils.NewSuccessfulRelease("pruned-release", testutils.TestNamespace, "pruned-snapshot", testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName)
+		pruned.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+		build(pruned)
+
+		candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Name).To(Equal(hotfixSnapshotName))
+	})
+
+	It("should use the branch defined in the application", func() {
+		app.Annotations = map[string]string{konflux.BranchAnnotation: zStreamBranch}
+		build()
+
+		candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Name).To(Equal(hotfixSnapshotName))
+	})
+
+	It("should not find candidates when the last snapshot of the branch was released", func() {
+		kornInstance.Branch = "main"
+		build()
+
+		candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+		Expect(err).To(HaveOccurred())
+		Expect(candidate).To(BeNil())
+	})
+
+	It("should list only the snapshots of the branch", func() {
+		kornInstance.Branch = zStreamBranch
+		build()
+
+		snapshots, err := kornInstance.ListSnapshots()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshots).To(HaveLen(2))
+		Expect(snapshots[0].Name).To(Equal(hotfixSnapshotName))
+		Expect(snapshots[1].Name).To(Equal(releasedZStreamName))
+	})
+})
@@ -0,0 +1,116 @@
+package konflux
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// BundleComponentsAnnotation lists in a bundle component the comma separated names of the components whose images the
+// bundle references. It is required when the application has more than one bundle component, so that each component
+// is validated against the bundle of its operator.
+const BundleComponentsAnnotation = "korn.redhat.io/bundle-components"
+
+const componentLabel = "appstudio.openshift.io/component"
+
+// bundleReference is a bundle component and the components whose images it references
+type bundleReference struct {
+	Bundle     applicationapiv1alpha1.Component
+	Components []applicationapiv1alpha1.Component
+}
+
+// resolveBundleReferences returns the components referenced by each bundle. A single bundle without the
+// BundleComponentsAnnotation references all the other components of the application. Otherwise every bundle must
+// declare its components, and every component must be referenced by one bundle.
+func resolveBundleReferences(bundles, comps []applicationapiv1alpha1.Component) ([]bundleReference, error) {
+	isBundle := func(c applicationapiv1alpha1.Component) bool {
+		return slices.ContainsFunc(bundles, func(b applicationapiv1alpha1.Component) bool { return b.Name == c.Name })
+	}
+	if len(bundles) == 1 {
+		if _, ok := bundles[0].Annotations[BundleComponentsAnnotation]; !ok {
+			ref := bundleReference{Bundle: bundles[0]}
+			for _, c := range comps {
+				if !isBundle(c) {
+					ref.Components = append(ref.Components, c)
+				}
+			}
+			return []bundleReference{ref}, nil
+		}
+	}
+	refs := []bundleReference{}
+	referenced := map[string]string{}
+	for _, b := range bundles {
+		value, ok := b.Annotations[BundleComponentsAnnotation]
+		if !ok {
+			return nil, fmt.Errorf("bundle component %s does not declare the components it references in annotation %s, which is required when the application has more than one bundle", b.Name, BundleComponentsAnnotation)
+		}
+		ref := bundleReference{Bundle: b}
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if len(name) == 0 {
+				continue
+			}
+			i := slices.IndexFunc(comps, func(c applicationapiv1alpha1.Component) bool { return c.Name == name })
+			switch {
+			case i < 0:
+				return nil, fmt.Errorf("component %s referenced by bundle %s not found in application %s", name, b.Name, b.Spec.Application)
+			case isBundle(comps[i]):
+				return nil, fmt.Errorf("bundle %s can't reference the bundle component %s", b.Name, name)
+			case len(referenced[name]) > 0:
+				return nil, fmt.Errorf("component %s is referenced by bundles %s and %s", name, referenced[name], b.Name)
+			}
+			referenced[name] = b.Name
+			ref.Components = append(ref.Components, comps[i])
+		}
+		refs = append(refs, ref)
+	}
+	for _, c := range comps {
+		if !isBundle(c) && len(referenced[c.Name]) == 0 {
+			return nil, fmt.Errorf("component %s is not referenced by any bundle: add it to the %s annotation of its bundle", c.Name, BundleComponentsAnnotation)
+		}
+	}
+	return refs, nil
+}
+
+// getBundleReferences returns the bundles of the operator application with the components each of them references
+func (k Korn) getBundleReferences() ([]bundleReference, error) {
+	bundles, err := k.GetBundleComponents()
+	if err != nil {
+		return nil, err
+	}
+	comps, err := k.ListComponents()
+	if err != nil {
+		return nil, err
+	}
+	return resolveBundleReferences(bundles, comps)
+}
+
+// componentSelector returns a selector for the resources with the labels that were created for any of the components
+func componentSelector(set map[string]string, comps []applicationapiv1alpha1.Component) (labels.Selector, error) {
+	selector := labels.SelectorFromSet(set)
+	if len(comps) == 0 {
+		return selector, nil
+	}
+	names := []string{}
+	for _, c := range comps {
+		names = append(names, c.Name)
+	}
+	req, err := labels.NewRequirement(componentLabel, selection.In, names)
+	if err != nil {
+		return nil, err
+	}
+	return selector.Add(*req), nil
+}
+
+// bundleNames returns the namespaced names of the bundles, separated by commas
+func bundleNames(refs []bundleReference) string {
+	names := []string{}
+	for _, r := range refs {
+		names = append(names, fmt.Sprintf("%s/%s", r.Bundle.Namespace, r.Bundle.Name))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,139 @@
+package konflux_test
+
+import (
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Multiple bundle components", func() {
+	const (
+		bundleAImage     = "registry.test.com/bundle-a@sha256:aaa111"
+		bundleBImage     = "registry.test.com/bundle-b@sha256:bbb222"
+		controllerAImage = "registry.test.com/controller-a@sha256:ccc333"
+		controllerBImage = "registry.test.com/controller-b@sha256:ddd444"
+	)
+	var kornInstance *konflux.Korn
+
+	bundle := func(name, refs string) *applicationapiv1alpha1.Component {
+		b := testutils.NewBundleComponent(name, testutils.TestNamespace, testutils.TestAppName)
+		if len(refs) > 0 {
+			b.Annotations = map[string]string{konflux.BundleComponentsAnnotation: refs}
+		}
+		return b
+	}
+
+	controller := func(name, label string) *applicationapiv1alpha1.Component {
+		return testutils.NewComponent(name, testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.BundleReferenceLabel: label})
+	}
+
+	snapshot := func() *applicationapiv1alpha1.Snapshot {
+		s := newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, "bundle-b")
+		s.Spec.Components = []applicationapiv1alpha1.SnapshotComponent{
+			{Name: "bundle-a", ContainerImage: bundleAImage},
+			{Name: "bundle-b", ContainerImage: bundleBImage},
+			{Name: "controller-a", ContainerImage: controllerAImage},
+			{Name: "controller-b", ContainerImage: controllerBImage},
+		}
+		return s
+	}
+
+	buildClient := func(objs ...runtime.Object) {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			append([]runtime.Object{
+				newNamespace(testutils.TestNamespace),
+				testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+				controller("controller-a", "controller-a"),
+				controller("controller-b", "controller-b"),
+			}, objs...)...,
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName).Build()
+	}
+
+	BeforeEach(func() {
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			PodClient: &mockImageClientLabels{labels: map[string]map[string]string{
+				bundleAImage: {"controller-a": controllerAImage},
+				bundleBImage: {"controller-b": controllerBImage},
+			}},
+		}
+	})
+
+	It("should return every bundle component of the application", func() {
+		buildClient(bundle("bundle-b", "controller-b"), bundle("bundle-a", "controller-a"))
+
+		bundles, err := kornInstance.GetBundleComponents()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bundles).To(HaveLen(2))
+		Expect(bundles[0].Name).To(Equal("bundle-a"))
+		Expect(bundles[1].Name).To(Equal("bundle-b"))
+	})
+
+	It("should select a snapshot built by any of the bundles when every bundle references its components", func() {
+		buildClient(bundle("bundle-a", "controller-a"), bundle("bundle-b", "controller-b"), snapshot())
+
+		candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Name).To(Equal(testutils.TestSnapshotName))
+	})
+
+	It("should reject the snapshot when one of the bundles does not reference the image of its component", func() {
+		buildClient(bundle("bundle-a", "controller-a"), bundle("bundle-b", "controller-b"), snapshot())
+		kornInstance.PodClient = &mockImageClientLabels{labels: map[string]map[string]string{
+			bundleAImage: {"controller-a": controllerAImage},
+			bundleBImage: {"controller-b": "registry.test.com/controller-b@sha256:eee555"},
+		}}
+
+		_, err := kornInstance.GetSnapshotCandidateForRelease()
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no new valid snapshot candidates found for application test-namespace/test-app"))
+	})
+
+	DescribeTable("should fail when the bundles don't declare their components",
+		func(objs []runtime.Object, expected string) {
+			buildClient(append(objs, snapshot())...)
+
+			_, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(expected))
+		},
+		Entry("without the annotation", []runtime.Object{
+			bundle("bundle-a", "controller-a"), bundle("bundle-b", ""),
+		}, "bundle component bundle-b does not declare the components it references"),
+		Entry("with an unknown component", []runtime.Object{
+			bundle("bundle-a", "controller-a,webhook"), bundle("bundle-b", "controller-b"),
+		}, "component webhook referenced by bundle bundle-a not found"),
+		Entry("with a component referenced twice", []runtime.Object{
+			bundle("bundle-a", "controller-a,controller-b"), bundle("bundle-b", "controller-b"),
+		}, "component controller-b is referenced by bundles bundle-a and bundle-b"),
+		Entry("with a component not referenced", []runtime.Object{
+			bundle("bundle-a", "controller-a"), bundle("bundle-b", " "),
+		}, "component controller-b is not referenced by any bundle"),
+		Entry("with a bundle referencing another bundle", []runtime.Object{
+			bundle("bundle-a", "controller-a,bundle-b"), bundle("bundle-b", "controller-b"),
+		}, "bundle bundle-a can't reference the bundle component bundle-b"),
+	)
+
+	It("should validate a single annotated bundle only against the components it declares", func() {
+		s := snapshot()
+		s.Labels[testutils.ComponentLabel] = "bundle-a"
+		buildClient(bundle("bundle-a", "controller-a,controller-b"), s)
+		kornInstance.PodClient = &mockImageClientLabels{labels: map[string]map[string]string{
+			bundleAImage: {"controller-a": controllerAImage, "controller-b": controllerBImage},
+		}}
+
+		candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(candidate.Name).To(Equal(testutils.TestSnapshotName))
+	})
+})
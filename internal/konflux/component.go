@@ -3,6 +3,8 @@ package konflux
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -15,22 +17,13 @@ func (k Korn) ListComponents() ([]applicationapiv1alpha1.Component, error) {
 }
 
 func (k Korn) ListComponentsWithMatchingLabels(labels client.MatchingLabels) ([]applicationapiv1alpha1.Component, error) {
-	list := applicationapiv1alpha1.ComponentList{}
-	err := k.KubeClient.List(context.TODO(), &list, &client.ListOptions{Namespace: k.Namespace}, labels)
-	if err != nil {
-		return nil, err
-	}
-	ret := []applicationapiv1alpha1.Component{}
-	if len(k.ApplicationName) == 0 {
-		return list.Items, nil
-	}
-	for _, c := range list.Items {
-		if c.Spec.Application == k.ApplicationName {
-			ret = append(ret, c)
-		}
-	}
-	return ret, nil
-
+	// Components are not labeled with their application, so they can only be filtered by it once retrieved
+	return listAll(k,
+		func(l *applicationapiv1alpha1.ComponentList) []applicationapiv1alpha1.Component { return l.Items },
+		func(c applicationapiv1alpha1.Component) bool {
+			return len(k.ApplicationName) == 0 || c.Spec.Application == k.ApplicationName
+		},
+		labels)
 }
 
 func (k Korn) GetComponent() (*applicationapiv1alpha1.Component, error) {
@@ -53,14 +46,19 @@ const (
 	EnvironmentLabel     = "korn.redhat.io/environment"
 	BundleReferenceLabel = "korn.redhat.io/bundle-label"
 
-	componentBundleType         = "bundle"
-	releaseEnvironmentStageType = "staging"
+	componentBundleType              = "bundle"
+	releaseEnvironmentStageType      = "staging"
+	releaseEnvironmentProductionType = "production"
 
-	operatorApplicationType = "operator"
-	fbcApplicationType      = "fbc"
+	operatorApplicationType       = "operator"
+	fbcApplicationType            = "fbc"
+	containerImageApplicationType = "container-image"
+	helmApplicationType           = "helm"
 )
 
-func (k Korn) GetBundleComponentForVersion() (*applicationapiv1alpha1.Component, error) {
+// GetBundleComponents returns the bundle components of the application sorted by name. Applications that ship several
+// operators have one bundle component per operator.
+func (k Korn) GetBundleComponents() ([]applicationapiv1alpha1.Component, error) {
 	l, err := k.ListComponentsWithMatchingLabels(client.MatchingLabels{ComponentTypeLabel: componentBundleType})
 	if err != nil {
 		return nil, err
@@ -68,15 +66,6 @@ func (k Korn) GetBundleComponentForVersion() (*applicationapiv1alpha1.Component,
 	if len(l) == 0 {
 		return nil, fmt.Errorf("no bundle component found for application %s/%s with labels %s=bundle", k.Namespace, k.ApplicationName, ComponentTypeLabel)
 	}
-	var comps []applicationapiv1alpha1.Component
-	for _, c := range l {
-		// filter out the ones that belong to this app
-		if c.Spec.Application == k.ApplicationName {
-			comps = append(comps, c)
-		}
-	}
-	if len(comps) > 1 {
-		return nil, fmt.Errorf("more than one bundle component found for application %s/%s: %+v", k.Namespace, k.ApplicationName, comps)
-	}
-	return &comps[0], nil
+	slices.SortFunc(l, func(a, b applicationapiv1alpha1.Component) int { return strings.Compare(a.Name, b.Name) })
+	return l, nil
 }
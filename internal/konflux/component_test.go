@@ -195,15 +195,15 @@ var _ = Describe("Component functionality", func() {
 		})
 	})
 
-	Context("GetBundleComponentForVersion functionality", func() {
+	Context("GetBundleComponents functionality", func() {
 		DescribeTable("should handle bundle component scenarios",
-			func(components []runtime.Object, expectError bool, expectedErrorSubstring string, expectedComponentName string, description string) {
+			func(components []runtime.Object, expectError bool, expectedErrorSubstring string, expectedComponentNames []string, description string) {
 				if len(components) > 0 {
 					fakeClientBuilder = fakeClientBuilder.WithRuntimeObjects(components...)
 				}
 				kornInstance.KubeClient = fakeClientBuilder.Build()
 
-				result, err := kornInstance.GetBundleComponentForVersion()
+				result, err := kornInstance.GetBundleComponents()
 
 				if expectError {
 					Expect(err).To(HaveOccurred(), description)
@@ -213,31 +213,32 @@ var _ = Describe("Component functionality", func() {
 					}
 				} else {
 					Expect(err).ToNot(HaveOccurred(), description)
-					Expect(result).ToNot(BeNil(), description)
-					if expectedComponentName != "" {
-						Expect(result.Name).To(Equal(expectedComponentName), description)
+					names := []string{}
+					for _, c := range result {
+						names = append(names, c.Name)
 					}
+					Expect(names).To(Equal(expectedComponentNames), description)
 				}
 			},
 
 			Entry("should return bundle component when exactly one exists for application",
-				getBundleWithControllerComponents(), false, "", bundleComponentName,
+				getBundleWithControllerComponents(), false, "", []string{bundleComponentName},
 				"Should return single bundle component"),
 
 			Entry("should ignore bundle components from other applications",
-				getBundleComponentsFromMultipleApps(), false, "", bundleComponentName,
+				getBundleComponentsFromMultipleApps(), false, "", []string{bundleComponentName},
 				"Should ignore other app bundle components"),
 
 			Entry("should return error when no bundle components exist",
-				getControllerOnlyComponents(), true, "no bundle component found for application test-namespace/test-app", "",
+				getControllerOnlyComponents(), true, "no bundle component found for application test-namespace/test-app", nil,
 				"Should fail when no bundle components"),
 
-			Entry("should return error when multiple bundle components exist for same application",
-				getMultipleBundleComponents(), true, "more than one bundle component found for application test-namespace/test-app", "",
-				"Should fail when multiple bundle components"),
+			Entry("should return every bundle component sorted by name when the application has several operators",
+				getMultipleBundleComponents(), false, "", []string{"bundle-component-1", "bundle-component-2"},
+				"Should return all bundle components"),
 
 			Entry("should return error when bundle components exist but none belong to the application",
-				getOtherAppBundleComponents(), true, "no bundle component found for application test-namespace/test-app", "",
+				getOtherAppBundleComponents(), true, "no bundle component found for application test-namespace/test-app", nil,
 				"Should fail when no matching app bundle components"),
 		)
 
@@ -245,7 +246,7 @@ var _ = Describe("Component functionality", func() {
 			kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
 			kornInstance.Namespace = "non-existent-namespace"
 
-			result, err := kornInstance.GetBundleComponentForVersion()
+			result, err := kornInstance.GetBundleComponents()
 
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(BeNil())
@@ -0,0 +1,192 @@
+package konflux
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// ComponentChange describes how a component differs between two snapshots
+type ComponentChange string
+
+const (
+	ComponentAdded   ComponentChange = "added"
+	ComponentRemoved ComponentChange = "removed"
+	ComponentChanged ComponentChange = "changed"
+)
+
+// SnapshotDiff contains the differences between the components of two snapshots. Components that are identical in
+// both snapshots are not included.
+type SnapshotDiff struct {
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	Components []ComponentDiff `json:"components"`
+}
+
+// ComponentDiff contains the differences of a component between two snapshots
+type ComponentDiff struct {
+	Name            string          `json:"name"`
+	Change          ComponentChange `json:"change"`
+	FromImage       string          `json:"fromImage,omitempty"`
+	ToImage         string          `json:"toImage,omitempty"`
+	FromRevision    string          `json:"fromRevision,omitempty"`
+	ToRevision      string          `json:"toRevision,omitempty"`
+	FromCommitTitle string          `json:"fromCommitTitle,omitempty"`
+	ToCommitTitle   string          `json:"toCommitTitle,omitempty"`
+	Labels          []LabelDiff     `json:"labels,omitempty"`
+}
+
+// LabelDiff contains the values of an image label that differs between the images of a component in two snapshots.
+// An empty value means the label is not defined in the image.
+type LabelDiff struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DiffSnapshot compares the snapshot k.BaseSnapshotName with k.SnapshotName. When k.AgainstLastRelease is set, the
+// snapshot used in the last successful release of the application is compared instead with k.SnapshotName, or with
+// the current candidate for release if no snapshot name is given.
+func (k Korn) DiffSnapshot() (*SnapshotDiff, error) {
+	var from, to *applicationapiv1alpha1.Snapshot
+	var err error
+	if len(k.SnapshotName) > 0 {
+		to, err = k.GetSnapshot()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !k.AgainstLastRelease {
+		if to == nil || len(k.BaseSnapshotName) == 0 {
+			return nil, errors.New("two snapshots are required to compare them")
+		}
+		k.SnapshotName = k.BaseSnapshotName
+		from, err = k.GetSnapshot()
+		if err != nil {
+			return nil, err
+		}
+		return k.DiffSnapshots(*from, *to)
+	}
+	if to != nil {
+		k.ApplicationName = to.Spec.Application
+	}
+	if len(k.ApplicationName) == 0 {
+		return nil, errors.New("application name is required to compare against the last release")
+	}
+	if k.Branch, err = k.getReleaseBranch(); err != nil {
+		return nil, err
+	}
+	from, err = k.getSnapshotFromLastRelease()
+	if err != nil {
+		return nil, err
+	}
+	if from == nil {
+		return nil, fmt.Errorf("no successful release found for application %s/%s", k.Namespace, k.ApplicationName)
+	}
+	if to == nil {
+		k.SnapshotName = ""
+		to, err = k.GetSnapshotCandidateForRelease()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return k.DiffSnapshots(*from, *to)
+}
+
+// DiffSnapshots returns the components that were added, removed or changed between the two snapshots. For changed
+// components, the commit titles of both git revisions and the image labels that differ are included. The repositories
+// cloned to read the commit titles are removed once compared.
+func (k Korn) DiffSnapshots(from, to applicationapiv1alpha1.Snapshot) (*SnapshotDiff, error) {
+	defer k.GitClient.Cleanup()
+	fromComps := map[string]applicationapiv1alpha1.SnapshotComponent{}
+	for _, c := range from.Spec.Components {
+		fromComps[c.Name] = c
+	}
+	toComps := map[string]applicationapiv1alpha1.SnapshotComponent{}
+	for _, c := range to.Spec.Components {
+		toComps[c.Name] = c
+	}
+	diff := SnapshotDiff{From: from.Name, To: to.Name, Components: []ComponentDiff{}}
+	for _, name := range sortedKeys(fromComps, toComps) {
+		f, inFrom := fromComps[name]
+		t, inTo := toComps[name]
+		d := ComponentDiff{Name: name}
+		switch {
+		case !inFrom:
+			d.Change = ComponentAdded
+			d.ToImage, d.ToRevision, d.ToCommitTitle = t.ContainerImage, gitRevision(t), k.commitTitle(t)
+		case !inTo:
+			d.Change = ComponentRemoved
+			d.FromImage, d.FromRevision, d.FromCommitTitle = f.ContainerImage, gitRevision(f), k.commitTitle(f)
+		case f.ContainerImage == t.ContainerImage && gitRevision(f) == gitRevision(t):
+			continue
+		default:
+			d.Change = ComponentChanged
+			d.FromImage, d.FromRevision = f.ContainerImage, gitRevision(f)
+			d.ToImage, d.ToRevision = t.ContainerImage, gitRevision(t)
+			if d.FromRevision != d.ToRevision {
+				d.FromCommitTitle, d.ToCommitTitle = k.commitTitle(f), k.commitTitle(t)
+			}
+			if d.FromImage != d.ToImage {
+				labels, err := k.diffImageLabels(d.FromImage, d.ToImage)
+				if err != nil {
+					return nil, err
+				}
+				d.Labels = labels
+			}
+		}
+		diff.Components = append(diff.Components, d)
+	}
+	return &diff, nil
+}
+
+func (k Korn) diffImageLabels(fromImage, toImage string) ([]LabelDiff, error) {
+	fromData, err := k.PodClient.GetImageData(fromImage)
+	if err != nil {
+		return nil, err
+	}
+	toData, err := k.PodClient.GetImageData(toImage)
+	if err != nil {
+		return nil, err
+	}
+	labels := []LabelDiff{}
+	for _, name := range sortedKeys(fromData.Labels, toData.Labels) {
+		if fromData.Labels[name] != toData.Labels[name] {
+			labels = append(labels, LabelDiff{Name: name, From: fromData.Labels[name], To: toData.Labels[name]})
+		}
+	}
+	return labels, nil
+}
+
+// sortedKeys returns the sorted union of the keys of both maps
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := map[string]V{}
+	maps.Copy(keys, a)
+	maps.Copy(keys, b)
+	return slices.Sorted(maps.Keys(keys))
+}
+
+func gitRevision(c applicationapiv1alpha1.SnapshotComponent) string {
+	if c.Source.GitSource == nil {
+		return ""
+	}
+	return c.Source.GitSource.Revision
+}
+
+// commitTitle returns the title of the commit the component was built from. Failing to retrieve it is not considered
+// an error, since the differences between the snapshots are still relevant without it.
+func (k Korn) commitTitle(c applicationapiv1alpha1.SnapshotComponent) string {
+	if c.Source.GitSource == nil || len(c.Source.GitSource.Revision) == 0 {
+		return ""
+	}
+	title, err := k.GitClient.GetCommitTitle(c.Source.GitSource.URL, c.Source.GitSource.Revision)
+	if err != nil {
+		logrus.Warnf("unable to retrieve the title of commit %s in %s for component %s: %v", c.Source.GitSource.Revision, c.Source.GitSource.URL, c.Name, err)
+		return ""
+	}
+	return title
+}
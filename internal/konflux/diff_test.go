@@ -0,0 +1,208 @@
+package konflux_test
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/containers/podman/v5/pkg/inspect"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	diffFromSnapshotName = "from-snapshot"
+	diffToSnapshotName   = "to-snapshot"
+)
+
+var _ = Describe("Snapshot diff", func() {
+	var (
+		kornInstance *konflux.Korn
+		from, to     *applicationapiv1alpha1.Snapshot
+	)
+
+	newComponent := func(name, image, revision string) applicationapiv1alpha1.SnapshotComponent {
+		return applicationapiv1alpha1.SnapshotComponent{
+			Name:           name,
+			ContainerImage: image,
+			Source: applicationapiv1alpha1.ComponentSource{
+				ComponentSourceUnion: applicationapiv1alpha1.ComponentSourceUnion{
+					GitSource: &applicationapiv1alpha1.GitSource{URL: "https://github.com/test/" + name, Revision: revision},
+				},
+			},
+		}
+	}
+
+	buildClient := func(objs ...runtime.Object) {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			append([]runtime.Object{
+				newNamespace(testutils.TestNamespace),
+				testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+				testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+				testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+				from, to,
+			}, objs...)...,
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName).Build()
+	}
+
+	BeforeEach(func() {
+		from = newFinishedSnapshot(diffFromSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		from.Spec.Components = []applicationapiv1alpha1.SnapshotComponent{
+			newComponent(testutils.BundleComponentName, "registry.test.com/bundle@sha256:111", "aaa"),
+			newComponent(testutils.ControllerComponentName, "registry.test.com/controller@sha256:222", "bbb"),
+			newComponent("webhook", "registry.test.com/webhook@sha256:333", "ccc"),
+		}
+		to = newFinishedSnapshot(diffToSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		to.Spec.Components = []applicationapiv1alpha1.SnapshotComponent{
+			newComponent(testutils.BundleComponentName, "registry.test.com/bundle@sha256:111", "aaa"),
+			newComponent(testutils.ControllerComponentName, "registry.test.com/controller@sha256:444", "ddd"),
+			newComponent("console", "registry.test.com/console@sha256:555", "eee"),
+		}
+		kornInstance = &konflux.Korn{
+			Namespace: testutils.TestNamespace,
+			PodClient: &mockImageClientLabels{labels: map[string]map[string]string{
+				"registry.test.com/controller@sha256:222": {"version": "v1.0.0", "release": "1", "vendor": "Red Hat"},
+				"registry.test.com/controller@sha256:444": {"version": "v1.0.1", "vendor": "Red Hat", "url": "https://example.com"},
+			}},
+			GitClient: &mockGitClientFixedVersion{version: "1.0.0"},
+		}
+	})
+
+	Context("DiffSnapshots functionality", func() {
+		It("should list the added, removed and changed components", func() {
+			d, err := kornInstance.DiffSnapshots(*from, *to)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.From).To(Equal(diffFromSnapshotName))
+			Expect(d.To).To(Equal(diffToSnapshotName))
+			Expect(d.Components).To(Equal([]konflux.ComponentDiff{
+				{
+					Name:          "console",
+					Change:        konflux.ComponentAdded,
+					ToImage:       "registry.test.com/console@sha256:555",
+					ToRevision:    "eee",
+					ToCommitTitle: "Commit eee",
+				},
+				{
+					Name:            testutils.ControllerComponentName,
+					Change:          konflux.ComponentChanged,
+					FromImage:       "registry.test.com/controller@sha256:222",
+					ToImage:         "registry.test.com/controller@sha256:444",
+					FromRevision:    "bbb",
+					ToRevision:      "ddd",
+					FromCommitTitle: "Commit bbb",
+					ToCommitTitle:   "Commit ddd",
+					Labels: []konflux.LabelDiff{
+						{Name: "release", From: "1", To: ""},
+						{Name: "url", From: "", To: "https://example.com"},
+						{Name: "version", From: "v1.0.0", To: "v1.0.1"},
+					},
+				},
+				{
+					Name:            "webhook",
+					Change:          konflux.ComponentRemoved,
+					FromImage:       "registry.test.com/webhook@sha256:333",
+					FromRevision:    "ccc",
+					FromCommitTitle: "Commit ccc",
+				},
+			}))
+		})
+
+		It("should remove the repositories cloned to read the commit titles", func() {
+			gitClient := &mockGitClientFixedVersion{version: "1.0.0"}
+			kornInstance.GitClient = gitClient
+
+			_, err := kornInstance.DiffSnapshots(*from, *to)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gitClient.cleanups).To(Equal(1))
+		})
+
+		It("should not report differences between identical snapshots", func() {
+			d, err := kornInstance.DiffSnapshots(*from, *from)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.Components).To(BeEmpty())
+		})
+
+		It("should not fail when the commit titles can't be retrieved", func() {
+			kornInstance.GitClient = &mockGitClientFixedVersion{err: fmt.Errorf("repository not found")}
+
+			d, err := kornInstance.DiffSnapshots(*from, *to)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.Components).To(HaveLen(3))
+			Expect(d.Components[1].ToCommitTitle).To(BeEmpty())
+		})
+
+		It("should fail when the images can't be inspected", func() {
+			kornInstance.PodClient = &mockImageClientError{}
+
+			_, err := kornInstance.DiffSnapshots(*from, *to)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("DiffSnapshot functionality", func() {
+		It("should compare two snapshots by name", func() {
+			buildClient()
+			kornInstance.BaseSnapshotName = diffFromSnapshotName
+			kornInstance.SnapshotName = diffToSnapshotName
+
+			d, err := kornInstance.DiffSnapshot()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.From).To(Equal(diffFromSnapshotName))
+			Expect(d.To).To(Equal(diffToSnapshotName))
+		})
+
+		It("should compare a snapshot against the last release", func() {
+			buildClient(testutils.NewSuccessfulRelease(testutils.TestReleaseName, testutils.TestNamespace, diffFromSnapshotName, testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName))
+			kornInstance.SnapshotName = diffToSnapshotName
+			kornInstance.AgainstLastRelease = true
+
+			d, err := kornInstance.DiffSnapshot()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.From).To(Equal(diffFromSnapshotName))
+			Expect(d.To).To(Equal(diffToSnapshotName))
+		})
+
+		DescribeTable("should fail to compare",
+			func(base, snapshot, app string, againstLastRelease bool, expected string) {
+				buildClient()
+				kornInstance.BaseSnapshotName = base
+				kornInstance.SnapshotName = snapshot
+				kornInstance.ApplicationName = app
+				kornInstance.AgainstLastRelease = againstLastRelease
+
+				d, err := kornInstance.DiffSnapshot()
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(expected))
+				Expect(d).To(BeNil())
+			},
+			Entry("with a single snapshot", "", diffToSnapshotName, "", false, "two snapshots are required"),
+			Entry("against the last release without application", "", "", "", true, "application name is required"),
+			Entry("against the last release without releases", "", diffToSnapshotName, "", true, "no successful release found"),
+		)
+	})
+})
+
+// Mock image client that returns the labels configured for each image
+type mockImageClientLabels struct {
+	labels map[string]map[string]string
+}
+
+func (m *mockImageClientLabels) GetImageData(image string) (*types.ImageInspectReport, error) {
+	return &types.ImageInspectReport{
+		ImageData: &inspect.ImageData{
+			Labels: m.labels[image],
+		},
+	}, nil
+}
@@ -0,0 +1,258 @@
+package konflux
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+)
+
+// CheckStatus is the outcome of each of the checks run by Diagnose
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "OK"
+	CheckWarning CheckStatus = "WARN"
+	CheckFailed  CheckStatus = "FAIL"
+)
+
+// Check is the result of verifying one aspect of the korn onboarding of an application. Fix describes how to solve
+// the problem found, when the check did not pass.
+type Check struct {
+	Name    string
+	Status  CheckStatus
+	Message string
+	Fix     string
+}
+
+const (
+	ApplicationTypeCheck      = "application-type"
+	BundleComponentCheck      = "bundle-component"
+	BundleLabelCheck          = "bundle-label"
+	ReleasePlanCheck          = "release-plan"
+	ReleasePlanAdmissionCheck = "release-plan-admission"
+	BundleImageCheck          = "bundle-image"
+)
+
+func passed(name, format string, args ...any) Check {
+	return Check{Name: name, Status: CheckPassed, Message: fmt.Sprintf(format, args...)}
+}
+
+func warning(name, fix, format string, args ...any) Check {
+	return Check{Name: name, Status: CheckWarning, Message: fmt.Sprintf(format, args...), Fix: fix}
+}
+
+func failed(name, fix, format string, args ...any) Check {
+	return Check{Name: name, Status: CheckFailed, Message: fmt.Sprintf(format, args...), Fix: fix}
+}
+
+// Diagnose verifies that the application and its components and release plans are labeled as korn expects, and that
+// the bundle image of the latest snapshot carries the label referenced by each component. Problems with the onboarding
+// are reported as failed checks, so an error is only returned when the resources can't be read.
+func (k Korn) Diagnose() ([]Check, error) {
+	app, err := k.GetApplication()
+	if err != nil {
+		return nil, err
+	}
+	comps, err := k.ListComponents()
+	if err != nil {
+		return nil, err
+	}
+	checks := []Check{}
+	appType := app.Labels[ApplicationTypeLabel]
+	switch appType {
+	case operatorApplicationType:
+		checks = append(checks, passed(ApplicationTypeCheck, "application %s is of type %s", app.Name, appType))
+		refs, bundleChecks := checkBundleComponent(comps)
+		checks = append(checks, bundleChecks...)
+		checks = append(checks, checkBundleLabels(comps)...)
+		if len(refs) > 0 {
+			c, err := k.checkBundleImage(refs)
+			if err != nil {
+				return nil, err
+			}
+			checks = append(checks, c...)
+		}
+	case fbcApplicationType:
+		checks = append(checks, passed(ApplicationTypeCheck, "application %s is of type %s", app.Name, appType))
+		if len(comps) != 1 {
+			checks = append(checks, failed(BundleComponentCheck,
+				"split the catalog of each OpenShift version into its own application",
+				"application %s of type %s has %d components, only 1 is supported", app.Name, appType, len(comps)))
+		}
+	default:
+		if _, ok := applicationTypes[appType]; ok {
+			checks = append(checks, passed(ApplicationTypeCheck, "application %s is of type %s", app.Name, appType))
+			if len(comps) == 0 {
+				checks = append(checks, failed(BundleComponentCheck,
+					fmt.Sprintf("create the components of application %s", app.Name),
+					"application %s has no component to release", app.Name))
+			}
+			break
+		}
+		msg := fmt.Sprintf("application %s has an invalid label %s=%s", app.Name, ApplicationTypeLabel, appType)
+		if len(appType) == 0 {
+			msg = fmt.Sprintf("application %s is not labeled with %s", app.Name, ApplicationTypeLabel)
+		}
+		checks = append(checks, failed(ApplicationTypeCheck,
+			fmt.Sprintf("oc label --overwrite application %s %s=<type>, where the type is one of %s", app.Name, ApplicationTypeLabel, strings.Join(ApplicationTypes(), ", ")),
+			"%s", msg))
+	}
+
+	rps, err := k.ListReleasePlans()
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, checkReleasePlans(app.Name, rps)...)
+	return checks, nil
+}
+
+// checkBundleComponent verifies that at least one component of the application is labeled as the bundle and, when
+// there are several, that each of them declares the components it references. It returns the bundles found with their
+// components.
+func checkBundleComponent(comps []applicationapiv1alpha1.Component) ([]bundleReference, []Check) {
+	bundles := []applicationapiv1alpha1.Component{}
+	for _, c := range comps {
+		if c.Labels[ComponentTypeLabel] == componentBundleType {
+			bundles = append(bundles, c)
+		}
+	}
+	if len(bundles) == 0 {
+		return nil, []Check{failed(BundleComponentCheck,
+			fmt.Sprintf("oc label component <bundle-component> %s=%s", ComponentTypeLabel, componentBundleType),
+			"no component is labeled with %s=%s", ComponentTypeLabel, componentBundleType)}
+	}
+	refs, err := resolveBundleReferences(bundles, comps)
+	if err != nil {
+		return nil, []Check{failed(BundleComponentCheck,
+			fmt.Sprintf("oc annotate --overwrite component <bundle-component> %s=<component>,<component>... for each bundle, or oc label component <not-a-bundle-component> %s-", BundleComponentsAnnotation, ComponentTypeLabel),
+			"%v", err)}
+	}
+	if len(refs) == 1 {
+		return refs, []Check{passed(BundleComponentCheck, "component %s is the bundle", refs[0].Bundle.Name)}
+	}
+	checks := []Check{}
+	for _, r := range refs {
+		names := []string{}
+		for _, c := range r.Components {
+			names = append(names, c.Name)
+		}
+		checks = append(checks, passed(BundleComponentCheck, "component %s is the bundle of %s", r.Bundle.Name, strings.Join(names, ", ")))
+	}
+	return refs, checks
+}
+
+// checkBundleLabels verifies that every component other than the bundle references the label of the bundle image
+// that contains its pullspec
+func checkBundleLabels(comps []applicationapiv1alpha1.Component) []Check {
+	checks := []Check{}
+	labeled := 0
+	for _, c := range comps {
+		if c.Labels[ComponentTypeLabel] == componentBundleType {
+			continue
+		}
+		if len(c.Labels[BundleReferenceLabel]) == 0 {
+			checks = append(checks, failed(BundleLabelCheck,
+				fmt.Sprintf("oc label component %s %s=<label-in-bundle-dockerfile>", c.Name, BundleReferenceLabel),
+				"component %s does not reference a label of the bundle image", c.Name))
+			continue
+		}
+		labeled++
+	}
+	if len(checks) == 0 {
+		return []Check{passed(BundleLabelCheck, "%d components reference a label of the bundle image", labeled)}
+	}
+	return checks
+}
+
+// checkBundleImage verifies that each bundle image in the latest snapshot of the bundle components has the labels
+// referenced by its components
+func (k Korn) checkBundleImage(refs []bundleReference) ([]Check, error) {
+	snapshots, err := k.listSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return []Check{warning(BundleImageCheck,
+			"push a change to the bundle to build a snapshot",
+			"no snapshot found for bundle %s, the labels of the bundle image can't be checked", bundleNames(refs))}, nil
+	}
+	checks := []Check{}
+	for _, r := range refs {
+		image, err := GetComponentPullspecFromSnapshot(snapshots[0], r.Bundle.Name)
+		if err != nil {
+			checks = append(checks, failed(BundleImageCheck, "", "%v", err))
+			continue
+		}
+		data, err := k.PodClient.GetImageData(image)
+		if err != nil {
+			checks = append(checks, failed(BundleImageCheck,
+				fmt.Sprintf("podman login %s", strings.SplitN(image, "/", 2)[0]),
+				"unable to inspect bundle image %s of snapshot %s: %v", image, snapshots[0].Name, err))
+			continue
+		}
+		for _, c := range r.Components {
+			label := c.Labels[BundleReferenceLabel]
+			if len(label) == 0 {
+				continue
+			}
+			if _, ok := data.Labels[label]; !ok {
+				checks = append(checks, failed(BundleImageCheck,
+					fmt.Sprintf("add LABEL %s=\"<pullspec of %s>\" to the Dockerfile of bundle %s, or fix the %s label of component %s", label, c.Name, r.Bundle.Name, BundleReferenceLabel, c.Name),
+					"bundle image %s of snapshot %s does not have the label %s referenced by component %s", image, snapshots[0].Name, label, c.Name))
+			}
+		}
+	}
+	if len(checks) == 0 {
+		return []Check{passed(BundleImageCheck, "bundle image of snapshot %s has the labels of all components", snapshots[0].Name)}, nil
+	}
+	return checks, nil
+}
+
+// checkReleasePlans verifies that the application has one release plan per environment and that each of them is
+// matched by an active ReleasePlanAdmission
+func checkReleasePlans(app string, rps []releaseapiv1alpha1.ReleasePlan) []Check {
+	if len(rps) == 0 {
+		return []Check{failed(ReleasePlanCheck,
+			fmt.Sprintf("create a ReleasePlan for application %s labeled with %s=<environment>", app, EnvironmentLabel),
+			"application %s has no release plan", app)}
+	}
+	checks := []Check{}
+	envs := map[string][]string{}
+	for _, rp := range rps {
+		env := rp.Labels[EnvironmentLabel]
+		if len(env) == 0 {
+			checks = append(checks, warning(ReleasePlanCheck,
+				fmt.Sprintf("oc label releaseplan %s %s=<environment>", rp.Name, EnvironmentLabel),
+				"release plan %s has no environment, it can only be used with --releaseplan", rp.Name))
+			continue
+		}
+		envs[env] = append(envs[env], rp.Name)
+		switch {
+		case len(rp.Status.ReleasePlanAdmission.Name) == 0:
+			checks = append(checks, failed(ReleasePlanAdmissionCheck,
+				fmt.Sprintf("ask the owners of namespace %s to add application %s to their ReleasePlanAdmission", rp.Spec.Target, app),
+				"release plan %s is not matched by any ReleasePlanAdmission in namespace %s", rp.Name, rp.Spec.Target))
+		case !rp.Status.ReleasePlanAdmission.Active:
+			checks = append(checks, failed(ReleasePlanAdmissionCheck,
+				fmt.Sprintf("ask the owners of namespace %s to activate ReleasePlanAdmission %s", rp.Spec.Target, rp.Status.ReleasePlanAdmission.Name),
+				"ReleasePlanAdmission %s of release plan %s is not active", rp.Status.ReleasePlanAdmission.Name, rp.Name))
+		default:
+			checks = append(checks, passed(ReleasePlanAdmissionCheck, "release plan %s is admitted by %s", rp.Name, rp.Status.ReleasePlanAdmission.Name))
+		}
+	}
+	for _, env := range slices.Sorted(maps.Keys(envs)) {
+		names := envs[env]
+		if len(names) > 1 {
+			checks = append(checks, failed(ReleasePlanCheck,
+				fmt.Sprintf("oc label releaseplan <unused-release-plan> %s-", EnvironmentLabel),
+				"environment %s has %d release plans: %s", env, len(names), strings.Join(names, ", ")))
+			continue
+		}
+		checks = append(checks, passed(ReleasePlanCheck, "environment %s is released with release plan %s", env, names[0]))
+	}
+	return checks
+}
@@ -0,0 +1,186 @@
+package konflux_test
+
+import (
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Onboarding diagnosis", func() {
+	var kornInstance *konflux.Korn
+
+	admitted := func(name, env string, active bool) *releaseapiv1alpha1.ReleasePlan {
+		rp := testutils.NewReleasePlan(name, testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.EnvironmentLabel: env})
+		rp.Spec.Target = testutils.TestManagedNamespace
+		rp.Status.ReleasePlanAdmission = releaseapiv1alpha1.MatchedReleasePlanAdmission{Name: testutils.TestManagedNamespace + "/" + name, Active: active}
+		return rp
+	}
+
+	buildClient := func(objs ...runtime.Object) {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			append([]runtime.Object{newNamespace(testutils.TestNamespace)}, objs...)...,
+		).Build()
+	}
+
+	annotatedBundle := func(name, refs string) *applicationapiv1alpha1.Component {
+		b := testutils.NewBundleComponent(name, testutils.TestNamespace, testutils.TestAppName)
+		b.Annotations = map[string]string{konflux.BundleComponentsAnnotation: refs}
+		return b
+	}
+
+	statuses := func(checks []konflux.Check, name string) []konflux.CheckStatus {
+		ret := []konflux.CheckStatus{}
+		for _, c := range checks {
+			if c.Name == name {
+				ret = append(ret, c.Status)
+			}
+		}
+		return ret
+	}
+
+	BeforeEach(func() {
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			PodClient: &mockImageClientLabels{labels: map[string]map[string]string{
+				testContainerImage: {"controller": "registry.test.com/controller@sha256:abc123"},
+			}},
+		}
+	})
+
+	It("should pass all checks of a well onboarded operator", func() {
+		buildClient(
+			testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+			newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName),
+			admitted("staging-rp", "staging", true),
+			admitted("production-rp", "production", true),
+		)
+
+		checks, err := kornInstance.Diagnose()
+
+		Expect(err).ToNot(HaveOccurred())
+		for _, c := range checks {
+			Expect(c.Status).To(Equal(konflux.CheckPassed), c.Message)
+			Expect(c.Fix).To(BeEmpty())
+		}
+		Expect(statuses(checks, konflux.BundleImageCheck)).To(HaveLen(1))
+		Expect(statuses(checks, konflux.ReleasePlanCheck)).To(HaveLen(2))
+		Expect(statuses(checks, konflux.ReleasePlanAdmissionCheck)).To(HaveLen(2))
+	})
+
+	It("should report the misconfigurations with their fixes", func() {
+		buildClient(
+			testutils.NewApplication(testutils.TestAppName, testutils.TestNamespace, nil),
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			admitted("staging-rp", "staging", false),
+			admitted("other-staging-rp", "staging", true),
+			testutils.NewReleasePlan("unlabeled-rp", testutils.TestNamespace, testutils.TestAppName, nil),
+		)
+
+		checks, err := kornInstance.Diagnose()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statuses(checks, konflux.ApplicationTypeCheck)).To(Equal([]konflux.CheckStatus{konflux.CheckFailed}))
+		Expect(statuses(checks, konflux.BundleComponentCheck)).To(BeEmpty())
+		Expect(statuses(checks, konflux.ReleasePlanCheck)).To(ConsistOf(konflux.CheckWarning, konflux.CheckFailed))
+		Expect(statuses(checks, konflux.ReleasePlanAdmissionCheck)).To(ConsistOf(konflux.CheckFailed, konflux.CheckPassed))
+		for _, c := range checks {
+			if c.Status != konflux.CheckPassed {
+				Expect(c.Fix).ToNot(BeEmpty(), c.Message)
+			}
+		}
+	})
+
+	DescribeTable("should check the components of an operator",
+		func(objs []runtime.Object, check string, expected []konflux.CheckStatus) {
+			buildClient(append([]runtime.Object{
+				testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+				admitted("staging-rp", "staging", true),
+			}, objs...)...)
+
+			checks, err := kornInstance.Diagnose()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statuses(checks, check)).To(Equal(expected))
+		},
+		Entry("without a bundle component", []runtime.Object{
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+		}, konflux.BundleComponentCheck, []konflux.CheckStatus{konflux.CheckFailed}),
+		Entry("with two bundle components", []runtime.Object{
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewBundleComponent("other-bundle", testutils.TestNamespace, testutils.TestAppName),
+		}, konflux.BundleComponentCheck, []konflux.CheckStatus{konflux.CheckFailed}),
+		Entry("with two bundle components declaring their components", []runtime.Object{
+			annotatedBundle(testutils.BundleComponentName, testutils.ControllerComponentName),
+			annotatedBundle("other-bundle", "webhook"),
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewComponent("webhook", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.BundleReferenceLabel: "webhook"}),
+		}, konflux.BundleComponentCheck, []konflux.CheckStatus{konflux.CheckPassed, konflux.CheckPassed}),
+		Entry("with components without bundle label", []runtime.Object{
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewComponent("webhook", testutils.TestNamespace, testutils.TestAppName, nil),
+			testutils.NewComponent("console", testutils.TestNamespace, testutils.TestAppName, nil),
+		}, konflux.BundleLabelCheck, []konflux.CheckStatus{konflux.CheckFailed, konflux.CheckFailed}),
+		Entry("without snapshots of the bundle", []runtime.Object{
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+		}, konflux.BundleImageCheck, []konflux.CheckStatus{konflux.CheckWarning}),
+		Entry("when the bundle image misses a referenced label", []runtime.Object{
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewComponent("webhook", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.BundleReferenceLabel: "webhook"}),
+			newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName),
+		}, konflux.BundleImageCheck, []konflux.CheckStatus{konflux.CheckFailed}),
+	)
+
+	It("should report the bundle images that can't be inspected and keep checking", func() {
+		kornInstance.PodClient = &mockImageClientError{}
+		buildClient(
+			testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+			newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName),
+			admitted("staging-rp", "staging", true),
+		)
+
+		checks, err := kornInstance.Diagnose()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statuses(checks, konflux.BundleImageCheck)).To(Equal([]konflux.CheckStatus{konflux.CheckFailed}))
+		Expect(statuses(checks, konflux.ReleasePlanCheck)).To(Equal([]konflux.CheckStatus{konflux.CheckPassed}))
+		for _, c := range checks {
+			if c.Name == konflux.BundleImageCheck {
+				Expect(c.Fix).To(Equal("podman login registry.test.com"))
+			}
+		}
+	})
+
+	It("should check the components of an FBC application", func() {
+		kornInstance.ApplicationName = testutils.OtherAppName
+		buildClient(
+			testutils.NewFBCApplication(testutils.OtherAppName, testutils.TestNamespace),
+			testutils.NewComponent("catalog", testutils.TestNamespace, testutils.OtherAppName, nil),
+			testutils.NewComponent("other-catalog", testutils.TestNamespace, testutils.OtherAppName, nil),
+		)
+
+		checks, err := kornInstance.Diagnose()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statuses(checks, konflux.ApplicationTypeCheck)).To(Equal([]konflux.CheckStatus{konflux.CheckPassed}))
+		Expect(statuses(checks, konflux.BundleComponentCheck)).To(Equal([]konflux.CheckStatus{konflux.CheckFailed}))
+		Expect(statuses(checks, konflux.ReleasePlanCheck)).To(Equal([]konflux.CheckStatus{konflux.CheckFailed}))
+	})
+
+	It("should fail when the application doesn't exist", func() {
+		buildClient()
+
+		_, err := kornInstance.Diagnose()
+
+		Expect(err).To(MatchError(ContainSubstring("application test-app not found")))
+	})
+})
@@ -0,0 +1,80 @@
+package konflux
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// PromotionOrderAnnotation defines in the application the environments its snapshots are promoted through, in order and
+// separated by commas, such as "development,staging,production". Without it, snapshots are promoted from staging to
+// production.
+const PromotionOrderAnnotation = "korn.redhat.io/promotion-order"
+
+// Environment is a target where an application is released, defined by the korn.redhat.io/environment label of the
+// release plan used to release it
+type Environment struct {
+	Name        string
+	ReleasePlan releaseapiv1alpha1.ReleasePlan
+}
+
+// ListEnvironments returns the environments defined by the release plans of the application, or by all the release
+// plans in the namespace when no application is provided, sorted by application and name
+func (k Korn) ListEnvironments() ([]Environment, error) {
+	rps, err := k.ListReleasePlans()
+	if err != nil {
+		return nil, err
+	}
+	envs := []Environment{}
+	for _, rp := range rps {
+		name, ok := rp.Labels[EnvironmentLabel]
+		if !ok || len(name) == 0 {
+			logrus.Debugf("ReleasePlan %s/%s has no %s label", rp.Namespace, rp.Name, EnvironmentLabel)
+			continue
+		}
+		envs = append(envs, Environment{Name: name, ReleasePlan: rp})
+	}
+	sort.SliceStable(envs, func(i, j int) bool {
+		if envs[i].ReleasePlan.Spec.Application != envs[j].ReleasePlan.Spec.Application {
+			return envs[i].ReleasePlan.Spec.Application < envs[j].ReleasePlan.Spec.Application
+		}
+		return envs[i].Name < envs[j].Name
+	})
+	return envs, nil
+}
+
+// GetPromotionOrder returns the environments the snapshots of the application are promoted through, in order
+func GetPromotionOrder(app applicationapiv1alpha1.Application) []string {
+	v, ok := app.Annotations[PromotionOrderAnnotation]
+	if !ok {
+		return []string{releaseEnvironmentStageType, releaseEnvironmentProductionType}
+	}
+	order := []string{}
+	for _, e := range strings.Split(v, ",") {
+		if e = strings.TrimSpace(e); len(e) > 0 {
+			order = append(order, e)
+		}
+	}
+	return order
+}
+
+// environmentNotFoundError returns the error for an environment without release plan, listing the environments
+// available for the application
+func (k Korn) environmentNotFoundError(environment string) error {
+	err := fmt.Errorf("no release plan found for application %s/%s with labels %s=%s", k.Namespace, k.ApplicationName, EnvironmentLabel, environment)
+	envs, lerr := k.ListEnvironments()
+	if lerr != nil || len(envs) == 0 {
+		return err
+	}
+	names := []string{}
+	for _, e := range envs {
+		names = append(names, e.Name)
+	}
+	slices.Sort(names)
+	return fmt.Errorf("%w: available environments are %s", err, strings.Join(slices.Compact(names), ", "))
+}
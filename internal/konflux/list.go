@@ -0,0 +1,37 @@
+package konflux
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// listPageSize is the maximum number of resources requested to the API server in each page when listing
+const listPageSize = 500
+
+// listAll lists the resources in the namespace in pages of listPageSize, following the continue token until the last
+// page. Only the items for which keep returns true are retained, so the resources filtered out are not held in memory
+// while the rest of the pages are retrieved. A nil keep retains all the items.
+func listAll[T any, L any, PL interface {
+	*L
+	client.ObjectList
+}](k Korn, items func(PL) []T, keep func(T) bool, opts ...client.ListOption) ([]T, error) {
+	ret := []T{}
+	opts = append(opts, client.InNamespace(k.Namespace), client.Limit(listPageSize))
+	var continueToken string
+	for {
+		list := PL(new(L))
+		if err := k.KubeClient.List(context.TODO(), list, append(opts, client.Continue(continueToken))...); err != nil {
+			return nil, err
+		}
+		for _, i := range items(list) {
+			if keep == nil || keep(i) {
+				ret = append(ret, i)
+			}
+		}
+		continueToken = list.GetContinue()
+		if len(continueToken) == 0 {
+			return ret, nil
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package konflux_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Listing resources", func() {
+	var (
+		kornInstance *konflux.Korn
+		objects      []runtime.Object
+	)
+
+	newAgedSnapshot := func(name string, age time.Duration) *applicationapiv1alpha1.Snapshot {
+		s := newFinishedSnapshot(name, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		s.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		return s
+	}
+
+	BeforeEach(func() {
+		objects = []runtime.Object{
+			newNamespace(testutils.TestNamespace),
+			newAgedSnapshot("snapshot-1", time.Hour),
+			newAgedSnapshot("snapshot-2", 2*time.Hour),
+			newAgedSnapshot("snapshot-3", 3*time.Hour),
+		}
+		kornInstance = &konflux.Korn{Namespace: testutils.TestNamespace}
+	})
+
+	It("should retrieve every page following the continue token", func() {
+		var requests []client.ListOptions
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				o := client.ListOptions{}
+				o.ApplyOptions(opts)
+				requests = append(requests, o)
+				if err := c.List(ctx, list, opts...); err != nil {
+					return err
+				}
+				// Serve a single snapshot per page
+				l := list.(*applicationapiv1alpha1.SnapshotList)
+				page := len(requests) - 1
+				l.Items = l.Items[page : page+1]
+				if page < 2 {
+					l.Continue = "next"
+				}
+				return nil
+			},
+		}).Build()
+
+		snapshots, err := kornInstance.ListSnapshots()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshots).To(HaveLen(3))
+		Expect(snapshots[0].Name).To(Equal("snapshot-1"))
+		Expect(snapshots[2].Name).To(Equal("snapshot-3"))
+		Expect(requests).To(HaveLen(3))
+		Expect(requests[0].Limit).To(BeEquivalentTo(500))
+		Expect(requests[0].Continue).To(BeEmpty())
+		Expect(requests[1].Continue).To(Equal("next"))
+	})
+
+	It("should list the newest snapshots first", func() {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(objects...).Build()
+
+		snapshots, err := kornInstance.ListSnapshots()
+
+		Expect(err).ToNot(HaveOccurred())
+		names := []string{}
+		for _, s := range snapshots {
+			names = append(names, s.Name)
+		}
+		Expect(names).To(Equal([]string{"snapshot-1", "snapshot-2", "snapshot-3"}))
+	})
+
+	It("should list the newest releases first", func() {
+		old := testutils.NewSuccessfulRelease("old-release", testutils.TestNamespace, "snapshot-3", testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName)
+		old.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		recent := testutils.NewSuccessfulRelease("recent-release", testutils.TestNamespace, "snapshot-1", testutils.TestReleasePlan, testutils.TestAppName, testutils.BundleComponentName)
+		recent.CreationTimestamp = metav1.NewTime(time.Now())
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(old, recent).Build()
+
+		releases, err := kornInstance.ListReleases()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(releases).To(HaveLen(2))
+		Expect(releases[0].Name).To(Equal("recent-release"))
+	})
+})
@@ -0,0 +1,255 @@
+package konflux
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	ptypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// bundleMediaTypeImageLabel and catalogConfigsImageLabel are set by the operator SDK and opm in the bundle and
+	// file based catalog images
+	bundleMediaTypeImageLabel = "operators.operatorframework.io.bundle.mediatype.v1"
+	catalogConfigsImageLabel  = "operators.operatorframework.io.index.configs.v1"
+)
+
+// environmentNameHints maps the words found in the names of the release plans to the environment they target. The
+// first match wins, so the more specific words go first.
+var environmentNameHints = []struct{ hint, environment string }{
+	{"stag", "staging"},
+	{"prod", "production"},
+	{"dev", "development"},
+}
+
+// LabelPatch is a korn label proposed for a resource by ProposeOnboarding, and the reason why it was inferred
+type LabelPatch struct {
+	Object client.Object
+	Kind   string
+	Key    string
+	Value  string
+	Reason string
+}
+
+// ProposeOnboarding infers the korn labels missing in the application, its components and release plans. The type of
+// the application and its bundle component are inferred from the names of the components and the labels of their
+// images in the latest snapshot, the bundle label of each component from the labels of the bundle image that reference
+// its image, and the environment of each release plan from its name. Labels already set are never changed, and the
+// ones that can't be inferred are skipped with a warning.
+func (k Korn) ProposeOnboarding() ([]LabelPatch, error) {
+	app, err := k.GetApplication()
+	if err != nil {
+		return nil, err
+	}
+	comps, err := k.ListComponents()
+	if err != nil {
+		return nil, err
+	}
+	images, snapshot, err := k.latestSnapshotImages(comps)
+	if err != nil {
+		return nil, err
+	}
+	patches := []LabelPatch{}
+
+	bundle, reason := inferBundleComponent(comps, images)
+	appType := app.Labels[ApplicationTypeLabel]
+	if len(appType) == 0 {
+		switch t, reason := inferApplicationType(comps, images, bundle); t {
+		case "":
+			logrus.Warnf("unable to infer the type of application %s: label it with %s=<type>, where the type is one of %s", app.Name, ApplicationTypeLabel, strings.Join(ApplicationTypes(), ", "))
+		default:
+			appType = t
+			patches = append(patches, LabelPatch{Object: app, Kind: "Application", Key: ApplicationTypeLabel, Value: t, Reason: reason})
+		}
+	}
+
+	if appType == operatorApplicationType {
+		bundles := []applicationapiv1alpha1.Component{}
+		for _, c := range comps {
+			if c.Labels[ComponentTypeLabel] == componentBundleType {
+				bundles = append(bundles, c)
+			}
+		}
+		switch {
+		case len(bundles) > 0:
+			// The bundles are already labeled, only their components are left to label
+		case bundle == nil:
+			logrus.Warnf("unable to infer the bundle component of application %s: label it with %s=%s", app.Name, ComponentTypeLabel, componentBundleType)
+		default:
+			bundles = append(bundles, *bundle)
+			patches = append(patches, LabelPatch{Object: bundle, Kind: "Component", Key: ComponentTypeLabel, Value: componentBundleType, Reason: reason})
+		}
+		if len(bundles) > 0 {
+			patches = append(patches, inferBundleLabels(comps, bundles, images, snapshot)...)
+		}
+	}
+
+	rps, err := k.ListReleasePlans()
+	if err != nil {
+		return nil, err
+	}
+	for i := range rps {
+		if _, ok := rps[i].Labels[EnvironmentLabel]; ok {
+			continue
+		}
+		env, hint := inferEnvironment(rps[i].Name)
+		if len(env) == 0 {
+			logrus.Warnf("unable to infer the environment of release plan %s: label it with %s=<environment>", rps[i].Name, EnvironmentLabel)
+			continue
+		}
+		patches = append(patches, LabelPatch{Object: &rps[i], Kind: "ReleasePlan", Key: EnvironmentLabel, Value: env, Reason: fmt.Sprintf("name contains %q", hint)})
+	}
+	return patches, nil
+}
+
+// ApplyOnboarding adds the labels to the resources with a merge patch, so that no other field is modified
+func (k Korn) ApplyOnboarding(patches []LabelPatch) error {
+	for _, p := range patches {
+		base := p.Object.DeepCopyObject().(client.Object)
+		labels := p.Object.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[p.Key] = p.Value
+		p.Object.SetLabels(labels)
+		if err := k.KubeClient.Patch(context.TODO(), p.Object, client.MergeFrom(base)); err != nil {
+			return fmt.Errorf("failed to label %s %s with %s=%s: %w", p.Kind, p.Object.GetName(), p.Key, p.Value, err)
+		}
+		logrus.Debugf("%s %s labeled with %s=%s", p.Kind, p.Object.GetName(), p.Key, p.Value)
+	}
+	return nil
+}
+
+// latestSnapshotImages returns the image data of the components in the latest push snapshot of the application,
+// indexed by component name. Images that can't be inspected are skipped, since the labels can still be inferred from
+// the names of the resources.
+func (k Korn) latestSnapshotImages(comps []applicationapiv1alpha1.Component) (map[string]*ptypes.ImageInspectReport, *applicationapiv1alpha1.Snapshot, error) {
+	snapshots, err := listAll(k,
+		func(l *applicationapiv1alpha1.SnapshotList) []applicationapiv1alpha1.Snapshot { return l.Items }, nil,
+		matchingLabelsPushEventType, client.MatchingLabels{"appstudio.openshift.io/application": k.ApplicationName})
+	if err != nil {
+		return nil, nil, err
+	}
+	images := map[string]*ptypes.ImageInspectReport{}
+	if len(snapshots) == 0 {
+		logrus.Warnf("no snapshot found for application %s, the labels are only inferred from the names of the resources", k.ApplicationName)
+		return images, nil, nil
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+	})
+	for _, c := range comps {
+		spec, err := GetComponentPullspecFromSnapshot(snapshots[0], c.Name)
+		if err != nil {
+			continue
+		}
+		data, err := k.PodClient.GetImageData(spec)
+		if err != nil {
+			logrus.Warnf("unable to inspect image %s of component %s: %v", spec, c.Name, err)
+			continue
+		}
+		images[c.Name] = data
+	}
+	return images, &snapshots[0], nil
+}
+
+// inferBundleComponent returns the component whose image is an operator bundle or, when no image could be inspected,
+// the only component with "bundle" in its name
+func inferBundleComponent(comps []applicationapiv1alpha1.Component, images map[string]*ptypes.ImageInspectReport) (*applicationapiv1alpha1.Component, string) {
+	var byImage, byName []int
+	for i, c := range comps {
+		if data, ok := images[c.Name]; ok && len(data.Labels[bundleMediaTypeImageLabel]) > 0 {
+			byImage = append(byImage, i)
+		}
+		if strings.Contains(c.Name, "bundle") {
+			byName = append(byName, i)
+		}
+	}
+	switch {
+	case len(byImage) == 1:
+		return &comps[byImage[0]], fmt.Sprintf("image has label %s", bundleMediaTypeImageLabel)
+	case len(byImage) == 0 && len(byName) == 1:
+		return &comps[byName[0]], `name contains "bundle"`
+	}
+	return nil, ""
+}
+
+// inferApplicationType returns operator when the application has a bundle component and fbc when its only component
+// is a file based catalog
+func inferApplicationType(comps []applicationapiv1alpha1.Component, images map[string]*ptypes.ImageInspectReport, bundle *applicationapiv1alpha1.Component) (string, string) {
+	if bundle != nil {
+		return operatorApplicationType, fmt.Sprintf("component %s is a bundle", bundle.Name)
+	}
+	if len(comps) != 1 {
+		return "", ""
+	}
+	if data, ok := images[comps[0].Name]; ok && len(data.Labels[catalogConfigsImageLabel]) > 0 {
+		return fbcApplicationType, fmt.Sprintf("image of component %s has label %s", comps[0].Name, catalogConfigsImageLabel)
+	}
+	for _, hint := range []string{"fbc", "catalog"} {
+		if strings.Contains(comps[0].Name, hint) {
+			return fbcApplicationType, fmt.Sprintf("name of component %s contains %q", comps[0].Name, hint)
+		}
+	}
+	return "", ""
+}
+
+// inferBundleLabels returns the bundle label of each component not labeled yet: the label of the bundle images whose
+// value references the same digest as the component's image in the snapshot
+func inferBundleLabels(comps, bundles []applicationapiv1alpha1.Component, images map[string]*ptypes.ImageInspectReport, snapshot *applicationapiv1alpha1.Snapshot) []LabelPatch {
+	patches := []LabelPatch{}
+	bundleImages := []*ptypes.ImageInspectReport{}
+	for _, b := range bundles {
+		if data, ok := images[b.Name]; ok {
+			bundleImages = append(bundleImages, data)
+		}
+	}
+	for i, c := range comps {
+		isBundle := slices.ContainsFunc(bundles, func(b applicationapiv1alpha1.Component) bool { return b.Name == c.Name })
+		if isBundle || len(c.Labels[BundleReferenceLabel]) > 0 || c.Labels[ComponentTypeLabel] == componentBundleType {
+			continue
+		}
+		if len(bundleImages) == 0 || snapshot == nil {
+			logrus.Warnf("unable to infer the bundle label of component %s without the bundle image: label it with %s=<label-in-bundle-dockerfile>", c.Name, BundleReferenceLabel)
+			continue
+		}
+		spec, err := GetComponentPullspecFromSnapshot(*snapshot, c.Name)
+		if err != nil || !strings.Contains(spec, "@sha256:") {
+			logrus.Warnf("component %s is not in snapshot %s: label it with %s=<label-in-bundle-dockerfile>", c.Name, snapshot.Name, BundleReferenceLabel)
+			continue
+		}
+		digest := spec[strings.LastIndex(spec, "@sha256:"):]
+		matches := []string{}
+		for _, data := range bundleImages {
+			for key, value := range data.Labels {
+				if strings.HasSuffix(value, digest) && !slices.Contains(matches, key) {
+					matches = append(matches, key)
+				}
+			}
+		}
+		if len(matches) != 1 {
+			logrus.Warnf("found %d labels in the bundle images referencing the image of component %s: label it with %s=<label-in-bundle-dockerfile>", len(matches), c.Name, BundleReferenceLabel)
+			continue
+		}
+		patches = append(patches, LabelPatch{Object: &comps[i], Kind: "Component", Key: BundleReferenceLabel, Value: matches[0],
+			Reason: fmt.Sprintf("bundle image label %s references its image", matches[0])})
+	}
+	return patches
+}
+
+// inferEnvironment returns the environment targeted by a release plan based on its name, and the word it was
+// inferred from
+func inferEnvironment(name string) (string, string) {
+	for _, h := range environmentNameHints {
+		if strings.Contains(strings.ToLower(name), h.hint) {
+			return h.environment, h.hint
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,133 @@
+package konflux_test
+
+import (
+	"context"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Onboarding", func() {
+	var (
+		kornInstance *konflux.Korn
+		bundleLabels map[string]string
+	)
+
+	buildClient := func(objs ...runtime.Object) {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			append([]runtime.Object{newNamespace(testutils.TestNamespace)}, objs...)...,
+		).Build()
+	}
+
+	proposed := func(patches []konflux.LabelPatch) map[string]string {
+		ret := map[string]string{}
+		for _, p := range patches {
+			ret[p.Object.GetName()+" "+p.Key] = p.Value
+		}
+		return ret
+	}
+
+	BeforeEach(func() {
+		bundleLabels = map[string]string{
+			"operators.operatorframework.io.bundle.mediatype.v1": "registry+v1",
+			"controller-rhel9-operator":                          "registry.stage.redhat.io/org/controller@sha256:abc123",
+		}
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			PodClient: &mockImageClientLabels{labels: map[string]map[string]string{
+				testContainerImage: bundleLabels,
+			}},
+		}
+	})
+
+	It("should infer the labels from the images of the latest snapshot and the names of the release plans", func() {
+		buildClient(
+			testutils.NewApplication(testutils.TestAppName, testutils.TestNamespace, nil),
+			testutils.NewComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+			testutils.NewComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+			newFinishedSnapshot(testutils.TestSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName),
+			testutils.NewReleasePlan("operator-stage-1-0", testutils.TestNamespace, testutils.TestAppName, nil),
+			testutils.NewReleasePlan("operator-prod-1-0", testutils.TestNamespace, testutils.TestAppName, nil),
+			testutils.NewReleasePlan("operator-1-0", testutils.TestNamespace, testutils.TestAppName, nil),
+		)
+
+		patches, err := kornInstance.ProposeOnboarding()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proposed(patches)).To(Equal(map[string]string{
+			testutils.TestAppName + " " + konflux.ApplicationTypeLabel:             "operator",
+			testutils.BundleComponentName + " " + konflux.ComponentTypeLabel:       "bundle",
+			testutils.ControllerComponentName + " " + konflux.BundleReferenceLabel: "controller-rhel9-operator",
+			"operator-stage-1-0 " + konflux.EnvironmentLabel:                       "staging",
+			"operator-prod-1-0 " + konflux.EnvironmentLabel:                        "production",
+		}))
+		for _, p := range patches {
+			Expect(p.Reason).ToNot(BeEmpty())
+		}
+	})
+
+	It("should fall back to the names of the components without snapshots", func() {
+		buildClient(
+			testutils.NewApplication(testutils.TestAppName, testutils.TestNamespace, nil),
+			testutils.NewComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+			testutils.NewComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+		)
+
+		patches, err := kornInstance.ProposeOnboarding()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proposed(patches)).To(Equal(map[string]string{
+			testutils.TestAppName + " " + konflux.ApplicationTypeLabel:       "operator",
+			testutils.BundleComponentName + " " + konflux.ComponentTypeLabel: "bundle",
+		}))
+	})
+
+	It("should infer file based catalog applications", func() {
+		kornInstance.ApplicationName = "fbc-v4-16"
+		buildClient(
+			testutils.NewApplication("fbc-v4-16", testutils.TestNamespace, nil),
+			testutils.NewComponent("catalog-v4-16", testutils.TestNamespace, "fbc-v4-16", nil),
+		)
+
+		patches, err := kornInstance.ProposeOnboarding()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proposed(patches)).To(Equal(map[string]string{"fbc-v4-16 " + konflux.ApplicationTypeLabel: "fbc"}))
+	})
+
+	It("should not propose the labels already set", func() {
+		buildClient(testutils.GetCompleteCreateReleaseTestSet()...)
+
+		patches, err := kornInstance.ProposeOnboarding()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patches).To(BeEmpty())
+	})
+
+	It("should apply the labels", func() {
+		buildClient(
+			testutils.NewApplication(testutils.TestAppName, testutils.TestNamespace, map[string]string{"team": "my-team"}),
+			testutils.NewComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName, nil),
+			testutils.NewReleasePlan("operator-staging", testutils.TestNamespace, testutils.TestAppName, nil),
+		)
+		patches, err := kornInstance.ProposeOnboarding()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patches).To(HaveLen(3))
+
+		Expect(kornInstance.ApplyOnboarding(patches)).To(Succeed())
+
+		app := applicationapiv1alpha1.Application{}
+		Expect(kornInstance.KubeClient.Get(context.TODO(), client.ObjectKey{Namespace: testutils.TestNamespace, Name: testutils.TestAppName}, &app)).To(Succeed())
+		Expect(app.Labels).To(Equal(map[string]string{"team": "my-team", konflux.ApplicationTypeLabel: "operator"}))
+		patches, err = kornInstance.ProposeOnboarding()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patches).To(BeEmpty())
+	})
+})
@@ -0,0 +1,119 @@
+package konflux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jordigilh/korn/internal"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OverrideLabel identifies the snapshots created by korn from a base snapshot with some component images replaced
+	OverrideLabel = "korn.redhat.io/override"
+	// OverrideBaseAnnotation stores the name of the snapshot the override was created from
+	OverrideBaseAnnotation = "korn.redhat.io/override-base"
+)
+
+// pipelinesAsCodePrefixes are the prefixes of the Pipelines as Code metadata of the build that produced a snapshot,
+// such as its commit SHA and event type, which doesn't describe an override snapshot
+var pipelinesAsCodePrefixes = []string{"pipelinesascode.tekton.dev/", "pac.test.appstudio.openshift.io/"}
+
+// GenerateOverrideSnapshot returns a new snapshot based on the snapshot k.BaseSnapshotName where the container image
+// of each component in k.ComponentOverrides is replaced. The images must be referenced by digest. The resulting snapshot
+// is validated as a release candidate, except for the status of its tests since they have not run yet. It doesn't keep
+// the Pipelines as Code labels of the base snapshot, so it is not taken for a snapshot built from a push event, but it
+// keeps the branch of the base snapshot.
+func (k Korn) GenerateOverrideSnapshot() (*applicationapiv1alpha1.Snapshot, error) {
+	if len(k.BaseSnapshotName) == 0 {
+		return nil, errors.New("base snapshot name is required")
+	}
+	if len(k.ComponentOverrides) == 0 {
+		return nil, errors.New("at least one component image override is required")
+	}
+	k.SnapshotName = k.BaseSnapshotName
+	base, err := k.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	k.ApplicationName = base.Spec.Application
+
+	components := slices.Clone(base.Spec.Components)
+	for name, image := range k.ComponentOverrides {
+		if _, err := digest.Parse(internal.ImageDigest(image)); err != nil {
+			return nil, fmt.Errorf("image %s for component %s must be referenced by digest: %v", image, name, err)
+		}
+		i := slices.IndexFunc(components, func(c applicationapiv1alpha1.SnapshotComponent) bool { return c.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("component %s not found in snapshot %s", name, base.Name)
+		}
+		logrus.Debugf("replacing image %s of component %s with %s", components[i].ContainerImage, name, image)
+		// The source the base image was built from does not apply to the new image
+		components[i] = applicationapiv1alpha1.SnapshotComponent{Name: name, ContainerImage: image}
+	}
+
+	labels := map[string]string{}
+	maps.Copy(labels, base.Labels)
+	delete(labels, ApprovalLabel)
+	maps.DeleteFunc(labels, func(key, _ string) bool {
+		return slices.ContainsFunc(pipelinesAsCodePrefixes, func(p string) bool { return strings.HasPrefix(key, p) })
+	})
+	labels[OverrideLabel] = "true"
+	annotations := map[string]string{OverrideBaseAnnotation: base.Name}
+	if branch := GetSnapshotBranch(*base); len(branch) > 0 {
+		annotations[targetBranchKey] = branch
+	}
+	snapshot := &applicationapiv1alpha1.Snapshot{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Snapshot",
+			APIVersion: applicationapiv1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			// The name is generated here instead of by the API server, so that it is known in dry run mode
+			Name:        fmt.Sprintf("%s-override-%s", base.Name, utilrand.String(5)),
+			Namespace:   base.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: applicationapiv1alpha1.SnapshotSpec{
+			Application:        base.Spec.Application,
+			DisplayName:        base.Spec.DisplayName,
+			DisplayDescription: fmt.Sprintf("Override of snapshot %s", base.Name),
+			Components:         components,
+		},
+	}
+
+	validate, err := k.getSnapshotValidator()
+	if err != nil {
+		return nil, err
+	}
+	v, err := validate(*snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if !v.Valid {
+		return nil, fmt.Errorf("override of snapshot %s is not a valid candidate for release: %s", base.Name, v.Message)
+	}
+	return snapshot, nil
+}
+
+func (k Korn) CreateSnapshot(snapshot applicationapiv1alpha1.Snapshot) (*applicationapiv1alpha1.Snapshot, error) {
+	opts := client.CreateOptions{}
+	if k.DryRun {
+		opts.DryRun = append(opts.DryRun, "all")
+	}
+	err := k.KubeClient.Create(context.Background(), &snapshot, &opts)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
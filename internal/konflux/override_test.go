@@ -0,0 +1,98 @@
+package konflux_test
+
+import (
+	"strings"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Override snapshots", func() {
+	var (
+		kornInstance *konflux.Korn
+		base         *applicationapiv1alpha1.Snapshot
+		builder      *fake.ClientBuilder
+	)
+
+	bundleImage := "registry.test.com/bundle@sha256:" + strings.Repeat("a", 64)
+	controllerImage := "registry.test.com/controller@sha256:" + strings.Repeat("b", 64)
+
+	BeforeEach(func() {
+		base = newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		base.Labels[konflux.ApprovalLabel] = string(konflux.RejectedState)
+		base.Labels["pipelinesascode.tekton.dev/sha"] = testSHA
+		base.Annotations = map[string]string{"pac.test.appstudio.openshift.io/source-branch": "refs/heads/release-1.0"}
+		for i := range base.Spec.Components {
+			base.Spec.Components[i].Source = applicationapiv1alpha1.ComponentSource{
+				ComponentSourceUnion: applicationapiv1alpha1.ComponentSourceUnion{
+					GitSource: &applicationapiv1alpha1.GitSource{URL: "https://github.com/test/repo.git", Revision: testSHA},
+				},
+			}
+		}
+		builder = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			newNamespace(testutils.TestNamespace),
+			testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+			base,
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName)
+		kornInstance = &konflux.Korn{
+			Namespace:        testutils.TestNamespace,
+			BaseSnapshotName: finishedSnapshotName,
+			KubeClient:       builder.Build(),
+			PodClient:        &mockImageClientValid{},
+			GitClient:        &mockGitClientFixedVersion{version: "1.0.0"},
+		}
+	})
+
+	It("should replace the component image and label the new snapshot", func() {
+		kornInstance.ComponentOverrides = map[string]string{testutils.BundleComponentName: bundleImage}
+
+		s, err := kornInstance.GenerateOverrideSnapshot()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Name).To(HavePrefix(finishedSnapshotName + "-override-"))
+		Expect(s.Spec.Application).To(Equal(testutils.TestAppName))
+		Expect(s.Labels).To(HaveKeyWithValue(konflux.OverrideLabel, "true"))
+		Expect(s.Labels).To(HaveKeyWithValue(testutils.ApplicationLabel, testutils.TestAppName))
+		Expect(s.Labels).ToNot(HaveKey(testutils.EventTypeLabel))
+		Expect(s.Labels).ToNot(HaveKey(testutils.SHALabel))
+		Expect(s.Labels).ToNot(HaveKey("pipelinesascode.tekton.dev/sha"))
+		Expect(s.Labels).ToNot(HaveKey(konflux.ApprovalLabel))
+		Expect(konflux.GetSnapshotBranch(*s)).To(Equal("release-1.0"))
+		Expect(s.Annotations).To(HaveKeyWithValue(konflux.OverrideBaseAnnotation, finishedSnapshotName))
+		Expect(s.Status.Conditions).To(BeEmpty())
+		for _, c := range s.Spec.Components {
+			if c.Name == testutils.BundleComponentName {
+				Expect(c.ContainerImage).To(Equal(bundleImage))
+				Expect(c.Source.GitSource).To(BeNil())
+			} else {
+				Expect(c.ContainerImage).To(Equal(base.Spec.Components[0].ContainerImage))
+				Expect(c.Source.GitSource).ToNot(BeNil())
+			}
+		}
+	})
+
+	DescribeTable("should fail to generate the override snapshot",
+		func(base string, overrides map[string]string, expected string) {
+			kornInstance.BaseSnapshotName = base
+			kornInstance.ComponentOverrides = overrides
+
+			s, err := kornInstance.GenerateOverrideSnapshot()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(expected))
+			Expect(s).To(BeNil())
+		},
+		Entry("without base snapshot", "", map[string]string{testutils.BundleComponentName: bundleImage}, "base snapshot name is required"),
+		Entry("without overrides", finishedSnapshotName, nil, "at least one component image override is required"),
+		Entry("with an unknown component", finishedSnapshotName, map[string]string{"unknown": bundleImage}, "component unknown not found"),
+		Entry("with an image referenced by tag", finishedSnapshotName, map[string]string{testutils.BundleComponentName: "registry.test.com/bundle:v1"}, "must be referenced by digest"),
+		Entry("when the bundle does not reference the new image", finishedSnapshotName, map[string]string{testutils.ControllerComponentName: controllerImage}, "pullspec mismatch"),
+	)
+
+})
@@ -7,7 +7,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
@@ -22,25 +21,32 @@ import (
 )
 
 func (k Korn) ListReleases() ([]releaseapiv1alpha1.Release, error) {
-	labels := client.MatchingLabels{}
+	labels := map[string]string{}
+	var comps []applicationapiv1alpha1.Component
 	if len(k.ApplicationName) > 0 {
-		comp, err := k.getComponentForRelease()
+		var err error
+		comps, err = k.getComponentsForRelease()
 		if err != nil {
 			return nil, err
 		}
 		labels["appstudio.openshift.io/application"] = k.ApplicationName
-		labels["appstudio.openshift.io/component"] = comp.Name
 	}
-	list := releaseapiv1alpha1.ReleaseList{}
-	err := k.KubeClient.List(context.TODO(), &list, &client.ListOptions{Namespace: k.Namespace}, labels)
+	selector, err := componentSelector(labels, comps)
 	if err != nil {
 		return nil, err
 	}
-	sort.Slice(list.Items,
+	releases, err := listAll(k,
+		func(l *releaseapiv1alpha1.ReleaseList) []releaseapiv1alpha1.Release { return l.Items },
+		nil,
+		client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(releases,
 		func(i, j int) bool {
-			return list.Items[j].ObjectMeta.CreationTimestamp.Before(&list.Items[j].ObjectMeta.CreationTimestamp)
+			return releases[j].ObjectMeta.CreationTimestamp.Before(&releases[i].ObjectMeta.CreationTimestamp)
 		})
-	return list.Items, nil
+	return releases, nil
 }
 
 func (k Korn) ListSuccessfulReleases() ([]releaseapiv1alpha1.Release, error) {
@@ -73,112 +79,56 @@ func (k Korn) GetRelease() (*releaseapiv1alpha1.Release, error) {
 	return &rel, nil
 }
 
-func (k Korn) getBundleVersionFromSnapshot(snapshot applicationapiv1alpha1.Snapshot) (string, error) {
+// getBundleVersionsFromSnapshot returns the version label of the image of each bundle component in the snapshot
+func (k Korn) getBundleVersionsFromSnapshot(snapshot applicationapiv1alpha1.Snapshot) ([]string, error) {
 
-	bundle, err := k.GetBundleComponentForVersion()
-	if err != nil {
-		return "", err
-	}
-	imgPullSpec, err := GetComponentPullspecFromSnapshot(snapshot, bundle.Name)
-	if err != nil {
-		return "", err
-	}
-	bundleData, err := k.PodClient.GetImageData(imgPullSpec)
-	if err != nil {
-		return "", err
-	}
-	if ver, ok := bundleData.Labels["version"]; ok {
-		return ver, nil
-	}
-	return "", fmt.Errorf("label 'version' not found in bundle %s/%s", bundle.Namespace, bundle.Name)
-}
-
-func (k Korn) GenerateReleaseManifest() (*releaseapiv1alpha1.Release, error) {
-	appType, err := k.GetApplicationType()
+	bundles, err := k.GetBundleComponents()
 	if err != nil {
 		return nil, err
 	}
-	if appType == operatorApplicationType {
-		return k.generateReleaseManifestForOperator()
-	}
-	if appType == fbcApplicationType {
-		return k.generateReleaseManifestForFBC()
+	versions := []string{}
+	for _, bundle := range bundles {
+		imgPullSpec, err := GetComponentPullspecFromSnapshot(snapshot, bundle.Name)
+		if err != nil {
+			return nil, err
+		}
+		bundleData, err := k.PodClient.GetImageData(imgPullSpec)
+		if err != nil {
+			return nil, err
+		}
+		ver, ok := bundleData.Labels["version"]
+		if !ok {
+			return nil, fmt.Errorf("label 'version' not found in bundle %s/%s", bundle.Namespace, bundle.Name)
+		}
+		versions = append(versions, ver)
 	}
-	return nil, fmt.Errorf("undefined application type %s for application %s/%s", appType, k.Namespace, k.ApplicationName)
+	return versions, nil
 }
 
-func (k Korn) generateReleaseManifestForFBC() (*releaseapiv1alpha1.Release, error) {
-	candidate, err := k.GetSnapshotCandidateForRelease()
+// GenerateReleaseManifest returns the release of the snapshot candidate of the application to the environment, with the
+// release notes generated by the handler of the application type
+func (k Korn) GenerateReleaseManifest() (*releaseapiv1alpha1.Release, error) {
+	handler, err := k.getApplicationTypeHandler()
 	if err != nil {
 		return nil, err
 	}
-	rtype := featureReleaseType
 	rp, err := k.getReleasePlanForEnvWithVersion(k.EnvironmentName)
 	if err != nil {
 		return nil, err
 	}
-
-	notes := map[string]ReleaseNote{
-		"releaseNotes": {
-			Type: rtype,
-		},
-	}
-	bnotes, err := json.Marshal(notes)
-	if err != nil {
-		return nil, err
+	if requiresApproval(*rp) {
+		logrus.Debugf("release plan %s/%s requires snapshots to be approved", rp.Namespace, rp.Name)
+		k.RequireApproval = true
 	}
-	r := releaseapiv1alpha1.Release{
-		ObjectMeta: v1.ObjectMeta{
-			GenerateName: fmt.Sprintf("%s-%s-", k.ApplicationName, k.EnvironmentName),
-			Namespace:    k.Namespace,
-		},
-		Spec: releaseapiv1alpha1.ReleaseSpec{
-			Snapshot:    candidate.Name,
-			ReleasePlan: rp.Name,
-			Data: &runtime.RawExtension{
-				Raw: bnotes,
-			},
-		},
-	}
-	return &r, nil
-}
-
-func (k Korn) generateReleaseManifestForOperator() (*releaseapiv1alpha1.Release, error) {
 	candidate, err := k.GetSnapshotCandidateForRelease()
 	if err != nil {
 		return nil, err
 	}
-	notes := map[string]ReleaseNote{}
-	if k.ReleaseNotes == nil {
-		rtype := featureReleaseType
-		appType, err := k.GetApplicationType()
-		if err != nil {
-			return nil, err
-		}
-		if appType == operatorApplicationType {
-			// Only fetch the release version when releasing an operator application type (bundle, etc...)
-			bundleVersion, err := k.getBundleVersionFromSnapshot(*candidate)
-			if err != nil {
-				return nil, err
-			}
-			semv, err := semver.ParseTolerant(bundleVersion)
-			if err != nil {
-				return nil, err
-			}
-			if semv.Patch != 0 {
-				rtype = bugReleaseType
-			}
-		}
-		notes["releaseNotes"] = ReleaseNote{Type: rtype}
-	} else {
-		notes["releaseNotes"] = *k.ReleaseNotes
-	}
-	rp, err := k.getReleasePlanForEnvWithVersion(k.EnvironmentName)
+	rn, err := handler.ReleaseNotes(k, *candidate)
 	if err != nil {
 		return nil, err
 	}
-
-	bnotes, err := json.Marshal(notes)
+	bnotes, err := json.Marshal(map[string]ReleaseNote{"releaseNotes": rn})
 	if err != nil {
 		return nil, err
 	}
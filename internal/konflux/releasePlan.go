@@ -11,19 +11,11 @@ import (
 )
 
 func (k Korn) ListReleasePlans() ([]releaseapiv1alpha1.ReleasePlan, error) {
-
-	list := releaseapiv1alpha1.ReleasePlanList{}
-	err := k.KubeClient.List(context.TODO(), &list, &client.ListOptions{Namespace: k.Namespace})
-	if err != nil {
-		return nil, err
-	}
-	ret := []releaseapiv1alpha1.ReleasePlan{}
-	for _, c := range list.Items {
-		if k.ApplicationName == "" || c.Spec.Application == k.ApplicationName {
-			ret = append(ret, c)
-		}
-	}
-	return ret, nil
+	return listAll(k,
+		func(l *releaseapiv1alpha1.ReleasePlanList) []releaseapiv1alpha1.ReleasePlan { return l.Items },
+		func(rp releaseapiv1alpha1.ReleasePlan) bool {
+			return k.ApplicationName == "" || rp.Spec.Application == k.ApplicationName
+		})
 }
 
 func (k Korn) GetReleasePlan() (*releaseapiv1alpha1.ReleasePlan, error) {
@@ -42,41 +34,16 @@ func (k Korn) GetReleasePlan() (*releaseapiv1alpha1.ReleasePlan, error) {
 }
 
 func (k Korn) getReleasePlanForEnvWithVersion(environment string) (*releaseapiv1alpha1.ReleasePlan, error) {
-	l := releaseapiv1alpha1.ReleasePlanList{}
-	labels := client.MatchingLabels{EnvironmentLabel: environment}
-	err := k.KubeClient.List(context.Background(), &l, &client.ListOptions{Namespace: k.Namespace}, &labels)
+	l, err := listAll(k,
+		func(l *releaseapiv1alpha1.ReleasePlanList) []releaseapiv1alpha1.ReleasePlan { return l.Items },
+		func(rp releaseapiv1alpha1.ReleasePlan) bool { return rp.Spec.Application == k.ApplicationName },
+		client.MatchingLabels{EnvironmentLabel: environment})
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range l.Items {
-		if v.Spec.Application == k.ApplicationName {
-			return &v, nil
-		}
+	if len(l) > 0 {
+		return &l[0], nil
 	}
 
-	return nil, fmt.Errorf("no release plan found for application %s/%s with labels %s=%s", k.Namespace, k.ApplicationName, EnvironmentLabel, environment)
+	return nil, k.environmentNotFoundError(environment)
 }
-
-// func getReleasePlanAdmission(namespace, application, environment, version string) (*releaseapiv1alpha1.ReleasePlanAdmission, error) {
-// 	rp, err := getReleasePlanForEnvWithVersion(namespace, application, environment, version)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	if !rp.Status.ReleasePlanAdmission.Active {
-// 		return nil, fmt.Errorf("no active Release Plan Admission available for Release Plan %s/%s", rp.Namespace, rp.Name)
-// 	}
-// 	kcli, err := internal.GetClient()
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	rpa := releaseapiv1alpha1.ReleasePlanAdmission{}
-// 	rpaNamespace, rpaName, err := cache.SplitMetaNamespaceKey(rp.Status.ReleasePlanAdmission.Name)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	err = kcli.Get(context.Background(), types.NamespacedName{Namespace: rpaNamespace, Name: rpaName}, &rpa, &client.GetOptions{})
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return &rpa, nil
-// }
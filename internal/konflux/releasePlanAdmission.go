@@ -0,0 +1,262 @@
+package konflux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/distribution/reference"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ListReleasePlanAdmissions returns the ReleasePlanAdmissions matched by the release plans of the application, or of
+// all the release plans in the namespace when no application is provided. The admissions live in the managed
+// namespaces targeted by the release plans.
+func (k Korn) ListReleasePlanAdmissions() ([]releaseapiv1alpha1.ReleasePlanAdmission, error) {
+	rps, err := k.ListReleasePlans()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	rpas := []releaseapiv1alpha1.ReleasePlanAdmission{}
+	for _, rp := range rps {
+		key := rp.Status.ReleasePlanAdmission.Name
+		if len(key) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		rpa, err := k.getReleasePlanAdmissionByKey(key)
+		if err != nil {
+			return nil, err
+		}
+		rpas = append(rpas, *rpa)
+	}
+	return rpas, nil
+}
+
+// GetReleasePlanAdmission returns the ReleasePlanAdmission by its name. The name can be prefixed with the managed
+// namespace where the admission lives, as in 'managed-namespace/name'. Otherwise the current namespace is used.
+func (k Korn) GetReleasePlanAdmission() (*releaseapiv1alpha1.ReleasePlanAdmission, error) {
+	key := k.ReleasePlanAdmissionName
+	if !strings.Contains(key, "/") {
+		key = k.Namespace + "/" + key
+	}
+	return k.getReleasePlanAdmissionByKey(key)
+}
+
+func (k Korn) getReleasePlanAdmissionByKey(key string) (*releaseapiv1alpha1.ReleasePlanAdmission, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	rpa := releaseapiv1alpha1.ReleasePlanAdmission{}
+	err = k.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, &rpa)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("ReleasePlanAdmission %s not found in namespace %s", name, namespace)
+		}
+		return nil, err
+	}
+	return &rpa, nil
+}
+
+// getReleasePlanAdmissionForReleasePlan returns the active ReleasePlanAdmission matched by the release plan
+func (k Korn) getReleasePlanAdmissionForReleasePlan(rp releaseapiv1alpha1.ReleasePlan) (*releaseapiv1alpha1.ReleasePlanAdmission, error) {
+	if len(rp.Status.ReleasePlanAdmission.Name) == 0 {
+		return nil, fmt.Errorf("release plan %s/%s is not matched by any ReleasePlanAdmission in namespace %s: ask the owners of the managed namespace to admit application %s", rp.Namespace, rp.Name, rp.Spec.Target, rp.Spec.Application)
+	}
+	if !rp.Status.ReleasePlanAdmission.Active {
+		return nil, fmt.Errorf("ReleasePlanAdmission %s for release plan %s/%s is not active", rp.Status.ReleasePlanAdmission.Name, rp.Namespace, rp.Name)
+	}
+	return k.getReleasePlanAdmissionByKey(rp.Status.ReleasePlanAdmission.Name)
+}
+
+// PreflightReleasePlanAdmission resolves the ReleasePlanAdmission that will process a release of the application in
+// the environment, and verifies that it admits the application and defines a pipeline and a policy. It returns nil
+// without error when the admission can't be read with the current credentials, so that the release can still be
+// created.
+func (k Korn) PreflightReleasePlanAdmission() (*releaseapiv1alpha1.ReleasePlanAdmission, error) {
+	rp, err := k.getReleasePlanForEnvWithVersion(k.EnvironmentName)
+	if err != nil {
+		return nil, err
+	}
+	rpa, err := k.getReleasePlanAdmissionForReleasePlan(*rp)
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			logrus.Warnf("Unable to read ReleasePlanAdmission %s, skipping the pre-flight checks: %v", rp.Status.ReleasePlanAdmission.Name, err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !slices.Contains(rpa.Spec.Applications, k.ApplicationName) {
+		return nil, fmt.Errorf("ReleasePlanAdmission %s/%s does not admit application %s", rpa.Namespace, rpa.Name, k.ApplicationName)
+	}
+	if rpa.Spec.Pipeline == nil {
+		return nil, fmt.Errorf("ReleasePlanAdmission %s/%s does not define a pipeline", rpa.Namespace, rpa.Name)
+	}
+	if len(rpa.Spec.Policy) == 0 {
+		return nil, fmt.Errorf("ReleasePlanAdmission %s/%s does not define a policy", rpa.Namespace, rpa.Name)
+	}
+	return rpa, nil
+}
+
+// GetReleasePlanAdmissionPipeline returns a description of the managed pipeline of the ReleasePlanAdmission: the
+// repository and revision of the pipeline when it is resolved from git, or the resolver and its parameters otherwise
+func GetReleasePlanAdmissionPipeline(rpa releaseapiv1alpha1.ReleasePlanAdmission) string {
+	if rpa.Spec.Pipeline == nil {
+		return ""
+	}
+	ref := rpa.Spec.Pipeline.PipelineRef
+	if url, revision, path, err := ref.GetGitResolverParams(); err == nil {
+		return fmt.Sprintf("%s@%s (%s)", url, revision, path)
+	}
+	params := []string{}
+	for _, p := range ref.Params {
+		params = append(params, fmt.Sprintf("%s=%s", p.Name, p.Value))
+	}
+	return fmt.Sprintf("%s(%s)", ref.Resolver, strings.Join(params, ","))
+}
+
+// releasePlanAdmissionData is the part of the ReleasePlanAdmission data read by the managed pipeline to push the
+// images of the snapshot components
+type releasePlanAdmissionData struct {
+	Mapping struct {
+		Components []componentMapping `json:"components"`
+		Defaults   struct {
+			Tags []string `json:"tags"`
+		} `json:"defaults"`
+	} `json:"mapping"`
+}
+
+type componentMapping struct {
+	Name       string   `json:"name"`
+	Repository string   `json:"repository"`
+	Tags       []string `json:"tags"`
+	// Repositories is the alternative to Repository used to push the image to several repositories
+	Repositories []struct {
+		URL  string   `json:"url"`
+		Tags []string `json:"tags"`
+	} `json:"repositories"`
+}
+
+// componentMappingApplicationTypes contains the application types whose managed pipelines push the images of the
+// snapshot components to the repositories in the data.mapping.components of the ReleasePlanAdmission
+var componentMappingApplicationTypes = []string{operatorApplicationType, containerImageApplicationType}
+
+var (
+	tagRegexp = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	// templateRegexp matches the variables that the managed pipeline replaces in the tags, such as {{ git_sha }}
+	templateRegexp = regexp.MustCompile(`{{\s*[\w.]+\s*}}`)
+)
+
+// ValidateReleaseMapping verifies that the components of the snapshot in the release are mapped in the
+// ReleasePlanAdmission that will process it. The validation is skipped with a warning for the application types that
+// are not released with a component mapping, such as file based catalogs.
+func (k Korn) ValidateReleaseMapping(rpa releaseapiv1alpha1.ReleasePlanAdmission, release releaseapiv1alpha1.Release) error {
+	appType, err := k.GetApplicationType()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(componentMappingApplicationTypes, appType) {
+		logrus.Warnf("Skipping the validation of the component mapping in ReleasePlanAdmission %s/%s: applications of type %s are not released with a component mapping", rpa.Namespace, rpa.Name, appType)
+		return nil
+	}
+	snapshot := applicationapiv1alpha1.Snapshot{}
+	err = k.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: k.Namespace, Name: release.Spec.Snapshot}, &snapshot)
+	if err != nil {
+		return err
+	}
+	return ValidateReleasePlanAdmissionMapping(rpa, snapshot)
+}
+
+// ValidateReleasePlanAdmissionMapping verifies that each component of the snapshot is mapped in the
+// data.mapping.components of the ReleasePlanAdmission to its own repositories, given either as a repository or as a
+// list of repositories, and that the repositories and tags are valid. Mapped components that are not in the snapshot
+// are only reported as a warning.
+func ValidateReleasePlanAdmissionMapping(rpa releaseapiv1alpha1.ReleasePlanAdmission, snapshot applicationapiv1alpha1.Snapshot) error {
+	data := releasePlanAdmissionData{}
+	if rpa.Spec.Data != nil && len(rpa.Spec.Data.Raw) > 0 {
+		if err := json.Unmarshal(rpa.Spec.Data.Raw, &data); err != nil {
+			return fmt.Errorf("failed to read the data of ReleasePlanAdmission %s/%s: %v", rpa.Namespace, rpa.Name, err)
+		}
+	}
+	if len(data.Mapping.Components) == 0 {
+		return fmt.Errorf("ReleasePlanAdmission %s/%s does not map any component in data.mapping.components", rpa.Namespace, rpa.Name)
+	}
+	var errs []error
+	mappings := map[string]componentMapping{}
+	repositories := map[string]string{}
+	for _, m := range data.Mapping.Components {
+		mappings[m.Name] = m
+		targets := map[string][]string{}
+		if len(m.Repository) > 0 {
+			targets[m.Repository] = m.Tags
+		}
+		for _, r := range m.Repositories {
+			targets[r.URL] = append(slices.Clone(m.Tags), r.Tags...)
+		}
+		if len(targets) == 0 {
+			errs = append(errs, fmt.Errorf("component %s has no repository in ReleasePlanAdmission %s/%s", m.Name, rpa.Namespace, rpa.Name))
+			continue
+		}
+		for _, repository := range slices.Sorted(maps.Keys(targets)) {
+			if err := validateMappingRepository(repository); err != nil {
+				errs = append(errs, fmt.Errorf("invalid repository %s for component %s in ReleasePlanAdmission %s/%s: %v", repository, m.Name, rpa.Namespace, rpa.Name, err))
+			}
+			if other, ok := repositories[repository]; ok && other != m.Name {
+				errs = append(errs, fmt.Errorf("components %s and %s are mapped to the same repository %s in ReleasePlanAdmission %s/%s", other, m.Name, repository, rpa.Namespace, rpa.Name))
+			}
+			repositories[repository] = m.Name
+			for _, t := range targets[repository] {
+				if !isValidMappingTag(t) {
+					errs = append(errs, fmt.Errorf("invalid tag %q for component %s in ReleasePlanAdmission %s/%s", t, m.Name, rpa.Namespace, rpa.Name))
+				}
+			}
+		}
+	}
+	for _, t := range data.Mapping.Defaults.Tags {
+		if !isValidMappingTag(t) {
+			errs = append(errs, fmt.Errorf("invalid default tag %q in ReleasePlanAdmission %s/%s", t, rpa.Namespace, rpa.Name))
+		}
+	}
+	inSnapshot := map[string]bool{}
+	for _, c := range snapshot.Spec.Components {
+		inSnapshot[c.Name] = true
+		if _, ok := mappings[c.Name]; !ok {
+			errs = append(errs, fmt.Errorf("component %s in snapshot %s is not mapped in ReleasePlanAdmission %s/%s", c.Name, snapshot.Name, rpa.Namespace, rpa.Name))
+		}
+	}
+	for _, m := range data.Mapping.Components {
+		if !inSnapshot[m.Name] {
+			logrus.Warnf("Component %s is mapped in ReleasePlanAdmission %s/%s but is not in snapshot %s", m.Name, rpa.Namespace, rpa.Name, snapshot.Name)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateMappingRepository verifies that the repository is a valid image name without tag or digest
+func validateMappingRepository(repository string) error {
+	named, err := reference.ParseNormalizedNamed(repository)
+	if err != nil {
+		return err
+	}
+	if !reference.IsNameOnly(named) {
+		return fmt.Errorf("the repository must not contain a tag or digest")
+	}
+	return nil
+}
+
+// isValidMappingTag returns true when the tag is valid once the managed pipeline replaces its variables
+func isValidMappingTag(tag string) bool {
+	return tagRegexp.MatchString(templateRegexp.ReplaceAllString(tag, "x"))
+}
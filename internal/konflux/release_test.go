@@ -127,7 +127,7 @@ var _ = Describe("Release functionality", func() {
 		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName)
 	})
 
-	Context("getBundleVersionFromSnapshot functionality", func() {
+	Context("getBundleVersionsFromSnapshot functionality", func() {
 		var (
 			kornInstance *konflux.Korn
 		)
@@ -184,3 +184,51 @@ func getMultiEnvironmentReleasePlans() []runtime.Object {
 		newProductionReleasePlan(productionReleasePlanName, testutils.TestNamespace, testutils.TestAppName),
 	}
 }
+
+var _ = Describe("Environment functionality", func() {
+	var kornInstance *konflux.Korn
+
+	BeforeEach(func() {
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			KubeClient: fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+				testutils.NewStagingReleasePlan("staging-releaseplan", testutils.TestNamespace, testutils.TestAppName),
+				testutils.NewReleasePlan("prod-early-access-releaseplan", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.EnvironmentLabel: "prod-early-access"}),
+				testutils.NewReleasePlan("dev-releaseplan", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.EnvironmentLabel: "dev"}),
+				testutils.NewReleasePlan("unlabeled-releaseplan", testutils.TestNamespace, testutils.TestAppName, nil),
+				testutils.NewReleasePlan("other-app-releaseplan", testutils.TestNamespace, testutils.OtherAppName, map[string]string{konflux.EnvironmentLabel: "stage-eus"}),
+			).Build(),
+		}
+	})
+
+	It("should discover the environments from the labels of the release plans of the application", func() {
+		envs, err := kornInstance.ListEnvironments()
+
+		Expect(err).ToNot(HaveOccurred())
+		names := []string{}
+		for _, e := range envs {
+			names = append(names, e.Name)
+		}
+		Expect(names).To(Equal([]string{"dev", "prod-early-access", "staging"}))
+	})
+
+	It("should list the environments of all the applications when no application is provided", func() {
+		kornInstance.ApplicationName = ""
+
+		envs, err := kornInstance.ListEnvironments()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(envs).To(HaveLen(4))
+		Expect(envs[0].Name).To(Equal("stage-eus"))
+		Expect(envs[0].ReleasePlan.Name).To(Equal("other-app-releaseplan"))
+	})
+
+	It("should list the available environments when releasing to an unknown one", func() {
+		kornInstance.EnvironmentName = "production"
+
+		_, err := kornInstance.PreflightReleasePlanAdmission()
+
+		Expect(err).To(MatchError("no release plan found for application test-namespace/test-app with labels korn.redhat.io/environment=production: available environments are dev, prod-early-access, staging"))
+	})
+})
@@ -0,0 +1,194 @@
+package konflux_test
+
+import (
+	"context"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("ReleasePlanAdmission functionality", func() {
+	var (
+		kornInstance *konflux.Korn
+		rp           *releaseapiv1alpha1.ReleasePlan
+		rpa          *releaseapiv1alpha1.ReleasePlanAdmission
+	)
+
+	BeforeEach(func() {
+		rp = testutils.NewAdmittedReleasePlan(testutils.TestReleasePlan, testutils.TestNamespace, testutils.TestAppName, testutils.TestReleasePlanAdmission)
+		rpa = testutils.NewReleasePlanAdmission(testutils.TestReleasePlanAdmission, testutils.TestAppName)
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			EnvironmentName: "staging",
+		}
+	})
+
+	build := func(objects ...runtime.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(objects...).Build()
+	}
+
+	Context("Pre-flight checks before creating a release", func() {
+		It("should return the admission that will process the release", func() {
+			kornInstance.KubeClient = build(rp, rpa)
+
+			result, err := kornInstance.PreflightReleasePlanAdmission()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Name).To(Equal(testutils.TestReleasePlanAdmission))
+			Expect(result.Namespace).To(Equal(testutils.TestManagedNamespace))
+		})
+
+		DescribeTable("should fail early",
+			func(mutate func(), expectedError string) {
+				mutate()
+				kornInstance.KubeClient = build(rp, rpa)
+
+				_, err := kornInstance.PreflightReleasePlanAdmission()
+
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			},
+			Entry("when the release plan is not matched by an admission",
+				func() { rp.Status.ReleasePlanAdmission = releaseapiv1alpha1.MatchedReleasePlanAdmission{} },
+				"is not matched by any ReleasePlanAdmission in namespace "+testutils.TestManagedNamespace),
+			Entry("when the admission is not active",
+				func() { rp.Status.ReleasePlanAdmission.Active = false },
+				"is not active"),
+			Entry("when the admission doesn't exist",
+				func() { rpa.Name = "other-admission" },
+				"ReleasePlanAdmission "+testutils.TestReleasePlanAdmission+" not found in namespace "+testutils.TestManagedNamespace),
+			Entry("when the admission doesn't admit the application",
+				func() { rpa.Spec.Applications = []string{testutils.OtherAppName} },
+				"does not admit application "+testutils.TestAppName),
+			Entry("when the admission doesn't define a pipeline",
+				func() { rpa.Spec.Pipeline = nil },
+				"does not define a pipeline"),
+			Entry("when the admission doesn't define a policy",
+				func() { rpa.Spec.Policy = "" },
+				"does not define a policy"),
+		)
+
+		It("should skip the checks when the admission can't be read", func() {
+			kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(rp, rpa).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*releaseapiv1alpha1.ReleasePlanAdmission); ok {
+						return apierrors.NewForbidden(schema.GroupResource{Group: "appstudio.redhat.com", Resource: "releaseplanadmissions"}, key.Name, nil)
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}).Build()
+
+			result, err := kornInstance.PreflightReleasePlanAdmission()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+	})
+
+	Context("Listing the admissions", func() {
+		It("should return each admission matched by the release plans once", func() {
+			production := testutils.NewAdmittedReleasePlan("production-releaseplan", testutils.TestNamespace, testutils.TestAppName, testutils.TestReleasePlanAdmission)
+			unmatched := testutils.NewStagingReleasePlan("unmatched-releaseplan", testutils.TestNamespace, testutils.TestAppName)
+			kornInstance.KubeClient = build(rp, production, unmatched, rpa)
+
+			result, err := kornInstance.ListReleasePlanAdmissions()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Name).To(Equal(testutils.TestReleasePlanAdmission))
+		})
+
+		It("should get an admission by its namespaced name", func() {
+			kornInstance.KubeClient = build(rpa)
+			kornInstance.ReleasePlanAdmissionName = testutils.TestManagedNamespace + "/" + testutils.TestReleasePlanAdmission
+
+			result, err := kornInstance.GetReleasePlanAdmission()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Spec.Policy).To(Equal("test-policy"))
+		})
+	})
+
+	Context("Validating the component mapping", func() {
+		withMapping := func(mapping string) releaseapiv1alpha1.ReleasePlanAdmission {
+			rpa.Spec.Data = &runtime.RawExtension{Raw: []byte(mapping)}
+			return *rpa
+		}
+
+		It("should accept an admission that maps every component of the snapshot", func() {
+			Expect(konflux.ValidateReleasePlanAdmissionMapping(*rpa, *testutils.NewTestSnapshot())).To(Succeed())
+		})
+
+		It("should accept mapped components that are not in the snapshot", func() {
+			mapping := `{"mapping":{"components":[{"name":"controller-component","repository":"quay.io/test/controller"},` +
+				`{"name":"bundle-component","repository":"quay.io/test/bundle"},{"name":"removed","repository":"quay.io/test/removed"}]}}`
+			Expect(konflux.ValidateReleasePlanAdmissionMapping(withMapping(mapping), *testutils.NewTestSnapshot())).To(Succeed())
+		})
+
+		It("should accept components mapped to a list of repositories", func() {
+			mapping := `{"mapping":{"components":[{"name":"controller-component","repositories":[{"url":"quay.io/test/controller","tags":["v1.0"]},` +
+				`{"url":"registry.test.com/controller"}]},{"name":"bundle-component","repository":"quay.io/test/bundle"}]}}`
+			Expect(konflux.ValidateReleasePlanAdmissionMapping(withMapping(mapping), *testutils.NewTestSnapshot())).To(Succeed())
+		})
+
+		It("should skip the validation for application types released without a component mapping", func() {
+			kornInstance.KubeClient = build(testutils.NewFBCApplication(testutils.TestAppName, testutils.TestNamespace))
+			release := releaseapiv1alpha1.Release{Spec: releaseapiv1alpha1.ReleaseSpec{Snapshot: testutils.TestSnapshotName}}
+
+			Expect(kornInstance.ValidateReleaseMapping(withMapping(`{"fbc":{}}`), release)).To(Succeed())
+		})
+
+		It("should validate the mapping for operator applications", func() {
+			kornInstance.KubeClient = build(testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace), testutils.NewTestSnapshot())
+			release := releaseapiv1alpha1.Release{Spec: releaseapiv1alpha1.ReleaseSpec{Snapshot: testutils.TestSnapshotName}}
+
+			Expect(kornInstance.ValidateReleaseMapping(withMapping(`{"fbc":{}}`), release)).To(MatchError(ContainSubstring("does not map any component")))
+		})
+
+		DescribeTable("should reject an invalid mapping",
+			func(mapping string, expectedError string) {
+				err := konflux.ValidateReleasePlanAdmissionMapping(withMapping(mapping), *testutils.NewTestSnapshot())
+
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			},
+			Entry("without components", `{"mapping":{}}`, "does not map any component in data.mapping.components"),
+			Entry("with a snapshot component missing",
+				`{"mapping":{"components":[{"name":"controller-component","repository":"quay.io/test/controller"}]}}`,
+				"component bundle-component in snapshot "+testutils.TestSnapshotName+" is not mapped"),
+			Entry("with a component without repository",
+				`{"mapping":{"components":[{"name":"controller-component","repository":"quay.io/test/controller"},{"name":"bundle-component"}]}}`,
+				"component bundle-component has no repository"),
+			Entry("with a repository that includes a tag",
+				`{"mapping":{"components":[{"name":"controller-component","repository":"quay.io/test/controller:v1"},{"name":"bundle-component","repository":"quay.io/test/bundle"}]}}`,
+				"invalid repository quay.io/test/controller:v1 for component controller-component"),
+			Entry("with a list of repositories that includes a digest",
+				`{"mapping":{"components":[{"name":"controller-component","repositories":[{"url":"quay.io/test/controller@sha256:0000000000000000000000000000000000000000000000000000000000000000"}]},{"name":"bundle-component","repository":"quay.io/test/bundle"}]}}`,
+				"invalid repository quay.io/test/controller@sha256"),
+			Entry("with an invalid tag in a list of repositories",
+				`{"mapping":{"components":[{"name":"controller-component","repositories":[{"url":"quay.io/test/controller","tags":["v1/0"]}]},{"name":"bundle-component","repository":"quay.io/test/bundle"}]}}`,
+				`invalid tag "v1/0" for component controller-component`),
+			Entry("with components sharing a repository",
+				`{"mapping":{"components":[{"name":"controller-component","repository":"quay.io/test/bundle"},{"name":"bundle-component","repository":"quay.io/test/bundle"}]}}`,
+				"components controller-component and bundle-component are mapped to the same repository quay.io/test/bundle"),
+			Entry("with an invalid tag",
+				`{"mapping":{"components":[{"name":"controller-component","repository":"quay.io/test/controller","tags":["v1/0"]},{"name":"bundle-component","repository":"quay.io/test/bundle"}]}}`,
+				`invalid tag "v1/0" for component controller-component`),
+			Entry("with an invalid default tag",
+				`{"mapping":{"components":[{"name":"controller-component","repository":"quay.io/test/controller"},{"name":"bundle-component","repository":"quay.io/test/bundle"}],"defaults":{"tags":["-{{ timestamp }}"]}}}`,
+				`invalid default tag "-{{ timestamp }}"`),
+		)
+	})
+
+	It("should describe the pipeline resolved from git", func() {
+		Expect(konflux.GetReleasePlanAdmissionPipeline(*rpa)).To(Equal("https://github.com/konflux-ci/release-service-catalog.git@production (pipelines/managed/rh-advisories/rh-advisories.yaml)"))
+	})
+})
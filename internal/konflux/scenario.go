@@ -0,0 +1,40 @@
+package konflux
+
+import (
+	"encoding/json"
+	"fmt"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IntegrationTestStatusAnnotation is set by the integration service in the snapshot with the status of each
+// IntegrationTestScenario run against it
+const IntegrationTestStatusAnnotation = "test.appstudio.openshift.io/status"
+
+// IntegrationTestScenarioStatus is the status of an IntegrationTestScenario run against a snapshot, as reported by the
+// integration service
+type IntegrationTestScenarioStatus struct {
+	ScenarioName string `json:"scenario"`
+	// Status is the state of the test, such as Pending, InProgress, TestPassed or TestFail
+	Status              string       `json:"status"`
+	Details             string       `json:"details,omitempty"`
+	TestPipelineRunName string       `json:"testPipelineRunName,omitempty"`
+	StartTime           *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime      *metav1.Time `json:"completionTime,omitempty"`
+	LastUpdateTime      *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// GetIntegrationTestStatuses returns the status of each IntegrationTestScenario run against the snapshot. It returns
+// no statuses when the integration service has not reported any yet.
+func GetIntegrationTestStatuses(snapshot applicationapiv1alpha1.Snapshot) ([]IntegrationTestScenarioStatus, error) {
+	statuses := []IntegrationTestScenarioStatus{}
+	a, ok := snapshot.Annotations[IntegrationTestStatusAnnotation]
+	if !ok || len(a) == 0 {
+		return statuses, nil
+	}
+	if err := json.Unmarshal([]byte(a), &statuses); err != nil {
+		return nil, fmt.Errorf("invalid integration test status in snapshot %s/%s: %v", snapshot.Namespace, snapshot.Name, err)
+	}
+	return statuses, nil
+}
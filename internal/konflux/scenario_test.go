@@ -0,0 +1,47 @@
+package konflux_test
+
+import (
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration test scenario statuses", func() {
+	It("should parse the status of each scenario from the snapshot annotation", func() {
+		s := newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		s.Annotations = map[string]string{konflux.IntegrationTestStatusAnnotation: `[
+			{"scenario":"enterprise-contract","status":"TestPassed","testPipelineRunName":"ec-abc12","startTime":"2025-01-01T10:00:00Z","completionTime":"2025-01-01T10:05:00Z","details":"Integration test passed"},
+			{"scenario":"e2e","status":"TestFail","testPipelineRunName":"e2e-def34","details":"Integration test failed"}
+		]`}
+
+		statuses, err := konflux.GetIntegrationTestStatuses(*s)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[0].ScenarioName).To(Equal("enterprise-contract"))
+		Expect(statuses[0].Status).To(Equal("TestPassed"))
+		Expect(statuses[0].TestPipelineRunName).To(Equal("ec-abc12"))
+		Expect(statuses[0].CompletionTime.Sub(statuses[0].StartTime.Time).Minutes()).To(BeEquivalentTo(5))
+		Expect(statuses[1].Status).To(Equal("TestFail"))
+		Expect(statuses[1].StartTime).To(BeNil())
+	})
+
+	It("should return no statuses when the annotation is missing", func() {
+		s := newPendingSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+
+		statuses, err := konflux.GetIntegrationTestStatuses(*s)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statuses).To(BeEmpty())
+	})
+
+	It("should fail when the annotation is not valid", func() {
+		s := newPendingSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		s.Annotations = map[string]string{konflux.IntegrationTestStatusAnnotation: "{"}
+
+		_, err := konflux.GetIntegrationTestStatuses(*s)
+
+		Expect(err).To(MatchError(ContainSubstring("invalid integration test status")))
+	})
+})
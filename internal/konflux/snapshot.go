@@ -4,12 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/blang/semver/v4"
+	ptypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/jordigilh/korn/internal"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,6 +25,9 @@ import (
 
 var (
 	matchingLabelsPushEventType = client.MatchingLabels{"pac.test.appstudio.openshift.io/event-type": "push"}
+
+	// ErrNoSnapshotCandidate is returned when none of the snapshots of the application can be released
+	ErrNoSnapshotCandidate = errors.New("no new valid snapshot candidates found")
 )
 
 func (k Korn) ListSnapshots() ([]applicationapiv1alpha1.Snapshot, error) {
@@ -29,27 +40,40 @@ func (k Korn) ListSnapshots() ([]applicationapiv1alpha1.Snapshot, error) {
 }
 
 func (k Korn) listSnapshots() ([]applicationapiv1alpha1.Snapshot, error) {
-	list := applicationapiv1alpha1.SnapshotList{}
-	labels := matchingLabelsPushEventType
+	// Copy the labels so that the application filter does not leak into subsequent calls
+	labels := maps.Clone(matchingLabelsPushEventType)
+	var comps []applicationapiv1alpha1.Component
 	if len(k.ApplicationName) > 0 {
-		comp, err := k.getComponentForRelease()
+		var err error
+		comps, err = k.getComponentsForRelease()
 		if err != nil {
 			return nil, err
 		}
-		labels["appstudio.openshift.io/component"] = comp.Name
+		labels["appstudio.openshift.io/application"] = k.ApplicationName
+	}
+	selector, err := componentSelector(labels, comps)
+	if err != nil {
+		return nil, err
 	}
-	logrus.Debugf("labels: %v", labels)
+	branch, err := k.getReleaseBranch()
+	if err != nil {
+		return nil, err
+	}
+	logrus.Debugf("labels: %v", selector)
 	logrus.Debugf("namespace: %s", k.Namespace)
-	err := k.KubeClient.List(context.TODO(), &list, &client.ListOptions{Namespace: k.Namespace}, labels)
+	snapshots, err := listAll(k,
+		func(l *applicationapiv1alpha1.SnapshotList) []applicationapiv1alpha1.Snapshot { return l.Items },
+		func(s applicationapiv1alpha1.Snapshot) bool { return isSnapshotFromBranch(s, branch) },
+		client.MatchingLabelsSelector{Selector: selector})
 	if err != nil {
 		return nil, err
 	}
-	logrus.Debugf("list of snapshots: %v", list.Items)
-	sort.Slice(list.Items,
+	logrus.Debugf("list of snapshots: %v", snapshots)
+	sort.Slice(snapshots,
 		func(i, j int) bool {
-			return list.Items[j].ObjectMeta.CreationTimestamp.Before(&list.Items[i].ObjectMeta.CreationTimestamp)
+			return snapshots[j].ObjectMeta.CreationTimestamp.Before(&snapshots[i].ObjectMeta.CreationTimestamp)
 		})
-	return list.Items, nil
+	return snapshots, nil
 }
 
 func (k Korn) GetSnapshotsByVersion() ([]applicationapiv1alpha1.Snapshot, error) {
@@ -103,93 +127,190 @@ func (k Korn) getVersionForSnapshot(snapshot applicationapiv1alpha1.Snapshot) (*
 	return version, true, nil
 }
 
-// getComponentForRelease returns the component to use for the release.
-// If the application is of "operator" type, the bundle component type is returned
-// For FBC based applications, which are expected only to contain one component, the default component is returned
-func (k Korn) getComponentForRelease() (*applicationapiv1alpha1.Component, error) {
-	appType, err := k.GetApplicationType()
+// getComponentsForRelease returns the components whose builds produce the snapshots to release, based on the type of
+// the application
+func (k Korn) getComponentsForRelease() ([]applicationapiv1alpha1.Component, error) {
+	handler, err := k.getApplicationTypeHandler()
 	if err != nil {
 		return nil, err
 	}
-	var comp *applicationapiv1alpha1.Component
-	switch appType {
-	case "operator":
-		comp, err = k.GetBundleComponentForVersion()
-		if err != nil {
-			return nil, err
-		}
-	case "fbc":
-		// Get the first and only component
-		comps, err := k.ListComponents()
-		if err != nil {
-			return nil, err
-		}
-		if len(comps) == 0 {
-			return nil, fmt.Errorf("application %s/%s does not have any component associated", k.Namespace, k.ApplicationName)
-		}
-		if len(comps) > 1 {
-			return nil, fmt.Errorf("application %s/%s of type FBC can only have 1 component per Konflux recommendation ", k.Namespace, k.ApplicationName)
-		}
-		comp = &comps[0]
-	}
-	return comp, nil
+	return handler.ReleaseComponents(k)
 }
 
+// getSnapshotFromLastRelease returns the snapshot of the last successful release built from k.Branch, which must be
+// resolved by the caller. Releases whose snapshot no longer exists are skipped.
 func (k Korn) getSnapshotFromLastRelease() (*applicationapiv1alpha1.Snapshot, error) {
-	var lastSnapshot *applicationapiv1alpha1.Snapshot
 	releasesForVersion, err := k.ListSuccessfulReleases()
 	if err != nil {
 		return nil, err
 	}
-	if len(releasesForVersion) > 0 {
+	for _, r := range releasesForVersion {
 		// Copy the last successful snapshot as the cutoff version
-		k.SnapshotName = releasesForVersion[0].Spec.Snapshot
-		lastSnapshot, err = k.GetSnapshot()
+		lastSnapshot := applicationapiv1alpha1.Snapshot{}
+		err := k.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: k.Namespace, Name: r.Spec.Snapshot}, &lastSnapshot)
+		if apierrors.IsNotFound(err) {
+			logrus.Debugf("snapshot %s of release %s not found, skipping release", r.Spec.Snapshot, r.Name)
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
+		// Releases of snapshots from other branches don't affect the candidates of this branch
+		if len(k.Branch) == 0 || GetSnapshotBranch(lastSnapshot) == k.Branch {
+			return &lastSnapshot, nil
+		}
 	}
-	return lastSnapshot, nil
+	return nil, nil
 }
 func (k Korn) GetSnapshotCandidateForRelease() (*applicationapiv1alpha1.Snapshot, error) {
 	if len(k.SnapshotName) > 0 || len(k.SHA) > 0 {
-		return k.GetSnapshot()
+		snapshot, err := k.GetSnapshot()
+		if err != nil {
+			return nil, err
+		}
+		if err := k.checkSnapshotApproval(*snapshot); err != nil {
+			return nil, err
+		}
+		return snapshot, nil
 	}
-	lastSnapshot, err := k.getSnapshotFromLastRelease()
+	branch, err := k.getReleaseBranch()
 	if err != nil {
 		return nil, err
 	}
-
-	comp, err := k.getComponentForRelease()
+	// Resolve the branch only once
+	k.Branch = branch
+	if k.GitClient != nil {
+		// Remove the repositories cloned to validate the source versions of the snapshots
+		defer k.GitClient.Cleanup()
+	}
+	lastSnapshot, err := k.getSnapshotFromLastRelease()
 	if err != nil {
 		return nil, err
 	}
+
 	list, err := k.ListSnapshots()
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range list {
+	for i, v := range list {
 		if lastSnapshot != nil && v.Name == lastSnapshot.Name {
+			list = list[:i]
 			break
 		}
-		valid, err := k.validateSnapshotCandidacy(comp.Name, v)
-		if err != nil {
-			return nil, err
-		}
-		if valid {
-			return &v, nil
-		}
+	}
+	// Inspect each image only once, regardless of how many snapshots reference it
+	k.PodClient = internal.NewMemoizedImageClient(k.PodClient)
+	validate, err := k.getSnapshotValidator()
+	if err != nil {
+		return nil, err
+	}
+	k, err = k.withVerdictOptions()
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := k.findFirstValidCandidate(validate, list)
+	if err != nil {
+		return nil, err
+	}
+	if candidate != nil {
+		return candidate, nil
 	}
 	if k.ForceRelease && lastSnapshot != nil {
 		// When force is enabled, we will at least return the last snapshot used, unless a newer one is detected. This ensures that the command
 		// will always trigger a build
+		if err := k.checkSnapshotApproval(*lastSnapshot); err != nil {
+			return nil, err
+		}
 		return lastSnapshot, nil
 	}
-	msg := fmt.Sprintf("no new valid snapshot candidates found for bundle %s/%s", comp.Namespace, comp.Name)
 	if lastSnapshot != nil {
-		msg += fmt.Sprintf(" after the one used for the last release %s", lastSnapshot.Name)
+		return nil, fmt.Errorf("%w for application %s/%s after the one used for the last release %s", ErrNoSnapshotCandidate, k.Namespace, k.ApplicationName, lastSnapshot.Name)
+	}
+	return nil, fmt.Errorf("%w for application %s/%s", ErrNoSnapshotCandidate, k.Namespace, k.ApplicationName)
+}
+
+// findFirstValidCandidate validates the snapshots concurrently using up to k.Workers goroutines and returns the first
+// snapshot in the list that is a valid candidate. Validation errors are only returned when they happen in a snapshot
+// that precedes the first valid candidate, so the result is the same as if the snapshots were validated sequentially.
+func (k Korn) findFirstValidCandidate(validate SnapshotValidator, snapshots []applicationapiv1alpha1.Snapshot) (*applicationapiv1alpha1.Snapshot, error) {
+	type result struct {
+		verdict Verdict
+		err     error
+	}
+	workers := max(k.Workers, 1)
+	results := make([]result, len(snapshots))
+	// cutoff holds the index of the first snapshot found to be valid or to fail validation. Snapshots after it don't
+	// need to be evaluated.
+	var cutoff atomic.Int64
+	cutoff.Store(int64(len(snapshots)))
+	lowerCutoff := func(i int) {
+		for {
+			c := cutoff.Load()
+			if int64(i) >= c || cutoff.CompareAndSwap(c, int64(i)) {
+				return
+			}
+		}
+	}
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if int64(i) > cutoff.Load() {
+					continue
+				}
+				v, err := k.validateSnapshotCandidacy(validate, snapshots[i])
+				results[i] = result{verdict: v, err: err}
+				if err == nil && k.RecordVerdicts {
+					k.recordVerdict(snapshots[i], v)
+				}
+				if v.Valid || err != nil {
+					lowerCutoff(i)
+				}
+			}
+		}()
 	}
-	return nil, errors.New(msg)
+	for i := range snapshots {
+		if int64(i) > cutoff.Load() {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for i, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		if r.verdict.Valid {
+			return &snapshots[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// SnapshotTestStatus returns the reason of the snapshot's AppStudioTestSucceeded condition, or an empty string when
+// its tests have not reported yet
+func SnapshotTestStatus(snapshot applicationapiv1alpha1.Snapshot) string {
+	if c := getConditionByType("AppStudioTestSucceeded", snapshot.Status.Conditions); c != nil {
+		return c.Reason
+	}
+	return ""
+}
+
+// SnapshotTestStatusDetails returns the reason of the snapshot's AppStudioTestSucceeded condition followed by its
+// message, if any
+func SnapshotTestStatusDetails(snapshot applicationapiv1alpha1.Snapshot) string {
+	c := getConditionByType("AppStudioTestSucceeded", snapshot.Status.Conditions)
+	switch {
+	case c == nil:
+		return ""
+	case len(c.Message) == 0:
+		return c.Reason
+	}
+	return fmt.Sprintf("%s: %s", c.Reason, c.Message)
 }
 
 func hasSnapshotCompletedSuccessfully(snapshot applicationapiv1alpha1.Snapshot) bool {
@@ -210,57 +331,98 @@ func GetComponentPullspecFromSnapshot(snapshot applicationapiv1alpha1.Snapshot,
 	return "", fmt.Errorf("component reference %s in snapshot %s not found", componentName, snapshot.Name)
 }
 
-func (k Korn) validateSnapshotCandidacy(bundleName string, snapshot applicationapiv1alpha1.Snapshot) (bool, error) {
+func (k Korn) validateSnapshotCandidacy(validate SnapshotValidator, snapshot applicationapiv1alpha1.Snapshot) (Verdict, error) {
 	if !hasSnapshotCompletedSuccessfully(snapshot) {
 		logrus.Debugf("snapshot %s has not finished running yet, discarding", snapshot.Name)
-		return false, nil
+		return Verdict{FailedRule: TestsSucceededRule, Message: fmt.Sprintf("snapshot %s has not finished running yet", snapshot.Name)}, nil
+	}
+	if v, ok := k.approvalVerdict(snapshot); ok {
+		return v, nil
+	}
+	if v, ok := k.getTrustedVerdict(snapshot); ok {
+		logrus.Debugf("using verdict recorded in snapshot %s by korn %s at %s", snapshot.Name, v.KornVersion, v.Timestamp.Format(time.RFC3339))
+		return *v, nil
 	}
+	return validate(snapshot)
+}
 
+// validateSnapshotImages validates the container images referenced by the snapshot against each bundle and the sources
+// of each component, regardless of the status of the snapshot's tests. Each bundle is validated independently against
+// the components it references.
+func (k Korn) validateSnapshotImages(refs []bundleReference, snapshot applicationapiv1alpha1.Snapshot) (Verdict, error) {
+	images := map[string]*ptypes.ImageInspectReport{}
+	for _, ref := range refs {
+		v, err := k.validateBundleImages(ref, snapshot, images)
+		if err != nil || len(v.FailedRule) > 0 {
+			return v, err
+		}
+	}
+	return k.validateSourceVersions(snapshot, images)
+}
+
+// validateBundleImages validates the images of the components referenced by the bundle in the snapshot against the
+// labels of the bundle image. The data of the images inspected is stored in images, indexed by component name.
+func (k Korn) validateBundleImages(ref bundleReference, snapshot applicationapiv1alpha1.Snapshot, images map[string]*ptypes.ImageInspectReport) (Verdict, error) {
+	bundleName := ref.Bundle.Name
 	bundleSpec, err := GetComponentPullspecFromSnapshot(snapshot, bundleName)
 	if err != nil {
-		return false, err
+		return Verdict{}, err
 	}
 
 	bundleData, err := k.PodClient.GetImageData(bundleSpec)
 	if err != nil {
-		return false, err
+		return Verdict{}, err
 	}
-	comps, err := k.ListComponents()
-	if err != nil {
-		return false, err
-	}
-	for _, c := range comps {
-		if c.Name == bundleName {
-			continue
-		}
+	images[bundleName] = bundleData
+	for _, c := range ref.Components {
 		compLabel, ok := c.Labels[BundleReferenceLabel]
 		if !ok {
-			return false, fmt.Errorf("label %s not found in component %s/%s", BundleReferenceLabel, snapshot.Namespace, snapshot.Spec.Application)
+			return Verdict{}, fmt.Errorf("label %s not found in component %s/%s", BundleReferenceLabel, c.Namespace, c.Name)
 		}
 		labelSpec, ok := bundleData.Labels[compLabel]
 		if !ok {
-			logrus.Infof("missing label %s for component %s in bundle container image %s", BundleReferenceLabel, c.Name, bundleSpec)
-			return false, nil
+			return rejectSnapshot(BundleReferenceRule, "missing label %s for component %s in bundle container image %s", compLabel, c.Name, bundleSpec), nil
 		}
 		snapshotSpec, err := GetComponentPullspecFromSnapshot(snapshot, c.Name)
 		if err != nil {
-			return false, err
+			return Verdict{}, err
 		}
 		// masage v and labelSpec to only compare the sha256 since the host and path will probably be different
 		if labelSpec[strings.LastIndex(labelSpec, "@sha256:"):] != snapshotSpec[strings.LastIndex(snapshotSpec, "@sha256:"):] {
-			logrus.Infof("component %s pullspec mismatch in bundle %s, snapshot is not a candidate for release", c.Name, bundleName)
-			return false, nil
+			return rejectSnapshot(BundleReferenceRule, "component %s pullspec mismatch in bundle %s, snapshot is not a candidate for release", c.Name, bundleName), nil
 		}
-		componentData, err := k.PodClient.GetImageData(bundleSpec)
+		componentData, err := k.PodClient.GetImageData(snapshotSpec)
 		if err != nil {
-			return false, err
+			return Verdict{}, err
+		}
+		images[c.Name] = componentData
+		if componentData.Labels[versionImageLabel] != bundleData.Labels[versionImageLabel] {
+			return rejectSnapshot(VersionConsistencyRule, "component %s and bundle %s version mismatch: component has %s and bundle has %s", c.Name, bundleSpec, componentData.Labels[versionImageLabel], bundleData.Labels[versionImageLabel]), nil
+		}
+		if k.CompareReleaseLabel && componentData.Labels[releaseImageLabel] != bundleData.Labels[releaseImageLabel] {
+			return rejectSnapshot(ReleaseConsistencyRule, "component %s and bundle %s release mismatch: component has %s and bundle has %s", c.Name, bundleSpec, componentData.Labels[releaseImageLabel], bundleData.Labels[releaseImageLabel]), nil
 		}
-		if componentData.Labels["version"] != bundleData.Labels["version"] {
-			logrus.Infof("component %s and bundle %s version mismatch: component has %s and bundle has %s", c.Name, bundleSpec, componentData.Labels["version"], bundleData.Labels["version"])
-			return false, nil
+	}
+	return Verdict{}, nil
+}
+
+// GetComponentVersions inspects the container image of each component in the snapshot and returns the values of
+// their version and release labels
+func (k Korn) GetComponentVersions(snapshot applicationapiv1alpha1.Snapshot) ([]ComponentVersion, error) {
+	versions := []ComponentVersion{}
+	for _, c := range snapshot.Spec.Components {
+		data, err := k.PodClient.GetImageData(c.ContainerImage)
+		if err != nil {
+			return nil, err
 		}
+		versions = append(versions, ComponentVersion{
+			Name:    c.Name,
+			Image:   c.ContainerImage,
+			Version: data.Labels[versionImageLabel],
+			Release: data.Labels[releaseImageLabel],
+		})
 	}
-	return true, nil
+	return versions, nil
 }
 
 func (k Korn) GetSnapshot() (*applicationapiv1alpha1.Snapshot, error) {
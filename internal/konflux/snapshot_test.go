@@ -6,11 +6,13 @@ package konflux_test
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
 	"github.com/containers/podman/v5/pkg/inspect"
+	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	"github.com/jordigilh/korn/testutils"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
@@ -81,8 +83,8 @@ var _ = Describe("Snapshot functionality", func() {
 				"Should handle empty snapshot list"),
 
 			Entry("should return snapshots for specific application when components exist",
-				testutils.TestAppName, append(getOperatorTestObjects(), getSimpleSnapshots()...), 2, false,
-				"Should filter snapshots by application components"),
+				testutils.TestAppName, append(getOperatorTestObjects(), getSimpleSnapshots()...), 1, false,
+				"Should filter snapshots by application and components"),
 
 			Entry("should return error when application type cannot be determined",
 				testutils.TestAppName, getSimpleSnapshots(), 0, true,
@@ -183,6 +185,31 @@ var _ = Describe("Standalone snapshot functions", func() {
 		)
 	})
 
+	Context("GetComponentVersions functionality", func() {
+		It("should return the version and release labels of each component image", func() {
+			snapshot := newFinishedSnapshot("test-snapshot", testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+			kornInstance := konflux.Korn{PodClient: &mockImageClientReleaseMismatch{}}
+
+			result, err := kornInstance.GetComponentVersions(*snapshot)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal([]konflux.ComponentVersion{
+				{Name: testutils.ControllerComponentName, Image: "registry.test.com/controller@sha256:abc123", Version: "v1.0.0", Release: "2"},
+				{Name: testutils.BundleComponentName, Image: testContainerImage, Version: "v1.0.0", Release: "1"},
+			}))
+		})
+
+		It("should return error when a component image cannot be inspected", func() {
+			snapshot := newFinishedSnapshot("test-snapshot", testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+			kornInstance := konflux.Korn{PodClient: &mockImageClientError{}}
+
+			result, err := kornInstance.GetComponentVersions(*snapshot)
+
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+	})
+
 	Context("GetComponentPullspecFromSnapshot functionality", func() {
 		It("should return component pullspec when component exists", func() {
 			snapshot := newFinishedSnapshot("test-snapshot", testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
@@ -360,6 +387,38 @@ func (m *mockImageClientVersionMismatch) GetImageData(image string) (*types.Imag
 	}, nil
 }
 
+type mockImageClientReleaseMismatch struct{}
+
+func (m *mockImageClientReleaseMismatch) GetImageData(image string) (*types.ImageInspectReport, error) {
+	release := "1"
+	if strings.Contains(image, "controller") {
+		release = "2" // Different release
+	}
+	return &types.ImageInspectReport{
+		ImageData: &inspect.ImageData{
+			Labels: map[string]string{
+				"controller": "registry.test.com/controller@sha256:abc123",
+				"version":    "v1.0.0",
+				"release":    release,
+			},
+		},
+	}, nil
+}
+
+// Mock image client that counts the inspections of each image and reports a version mismatch between the controller
+// and the bundle
+type mockImageClientCounter struct {
+	mu          sync.Mutex
+	inspections map[string]int
+}
+
+func (m *mockImageClientCounter) GetImageData(image string) (*types.ImageInspectReport, error) {
+	m.mu.Lock()
+	m.inspections[image]++
+	m.mu.Unlock()
+	return (&mockImageClientVersionMismatch{}).GetImageData(image)
+}
+
 // validateSnapshotCandidacy tests - testing through public API
 var _ = Describe("validateSnapshotCandidacy functionality", func() {
 	var (
@@ -651,6 +710,36 @@ var _ = Describe("validateSnapshotCandidacy functionality", func() {
 		})
 	})
 
+	Context("Release label consistency", func() {
+		DescribeTable("should only compare the release labels when requested",
+			func(compareRelease, expectCandidate bool) {
+				snapshot := newFinishedSnapshot("valid-snapshot", testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+				components := []runtime.Object{
+					testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+					testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+				}
+				application := testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace)
+
+				fakeClientBuilder = fakeClientBuilder.WithRuntimeObjects(append(components, snapshot, application)...)
+				kornInstance.KubeClient = fakeClientBuilder.Build()
+				kornInstance.PodClient = &mockImageClientReleaseMismatch{}
+				kornInstance.CompareReleaseLabel = compareRelease
+
+				result, err := kornInstance.GetSnapshotCandidateForRelease()
+
+				if expectCandidate {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).ToNot(BeNil())
+				} else {
+					Expect(err).To(HaveOccurred())
+					Expect(result).To(BeNil())
+				}
+			},
+			Entry("when the release comparison is disabled", false, true),
+			Entry("when the release comparison is enabled", true, false),
+		)
+	})
+
 	Context("Successful validation", func() {
 		It("should return true when all validations pass", func() {
 			// Create a valid snapshot
@@ -927,6 +1016,97 @@ var _ = Describe("validateSnapshotCandidacy functionality", func() {
 		})
 	})
 
+	Context("Source version consistency", func() {
+		DescribeTable("should cross-check the image version labels against the VERSION.txt in the source repository",
+			func(gitClient *mockGitClientFixedVersion, expectCandidate bool) {
+				snapshot := createSnapshotWithGitSource("valid-snapshot", "commit1", 1)
+				builder := setupVersionCandidateTest(kornInstance, nil)
+				builder.WithRuntimeObjects(snapshot)
+				kornInstance.KubeClient = builder.Build()
+				kornInstance.GitClient = gitClient
+
+				result, err := kornInstance.GetSnapshotCandidateForRelease()
+
+				if expectCandidate {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).ToNot(BeNil())
+					Expect(result.Name).To(Equal("valid-snapshot"))
+				} else {
+					Expect(err).To(HaveOccurred())
+					Expect(result).To(BeNil())
+				}
+			},
+			Entry("when the source version matches the image labels", &mockGitClientFixedVersion{version: "1.0.0"}, true),
+			Entry("when the source version drifts from the image labels", &mockGitClientFixedVersion{version: "1.1.0"}, false),
+			Entry("when the source repository does not contain a version file", &mockGitClientFixedVersion{err: internal.ErrVersionFileNotFound}, true),
+			Entry("when the source version cannot be retrieved", &mockGitClientFixedVersion{err: fmt.Errorf("repository not found")}, false),
+		)
+
+		It("should remove the repositories cloned to validate the candidates", func() {
+			builder := setupVersionCandidateTest(kornInstance, nil)
+			builder.WithRuntimeObjects(createSnapshotWithGitSource("valid-snapshot", "commit1", 1))
+			kornInstance.KubeClient = builder.Build()
+			gitClient := &mockGitClientFixedVersion{version: "1.0.0"}
+			kornInstance.GitClient = gitClient
+
+			_, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gitClient.cleanups).To(Equal(1))
+		})
+	})
+
+	Context("Concurrent candidate evaluation", func() {
+		createSnapshotsForConcurrency := func() []runtime.Object {
+			objs := []runtime.Object{}
+			for i := range 8 {
+				snapshot := newFinishedSnapshot(fmt.Sprintf("snapshot-%d", i), testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+				snapshot.ObjectMeta.CreationTimestamp = metav1.NewTime(metav1.Now().Add(time.Duration(-i) * time.Hour))
+				objs = append(objs, snapshot)
+			}
+			return objs
+		}
+
+		It("should return the newest valid candidate regardless of the number of workers", func() {
+			snapshots := createSnapshotsForConcurrency()
+			// The two newest snapshots have not finished their tests yet
+			for _, s := range snapshots[:2] {
+				s.(*applicationapiv1alpha1.Snapshot).Status.Conditions[0].Reason = "InProgress"
+			}
+			builder := setupVersionCandidateTest(kornInstance, &mockGitClientWithVersions{})
+			builder.WithRuntimeObjects(snapshots...)
+			kornInstance.KubeClient = builder.Build()
+
+			for _, workers := range []int{0, 1, 3, 8, 16} {
+				kornInstance.Workers = workers
+				result, err := kornInstance.GetSnapshotCandidateForRelease()
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+				Expect(result.Name).To(Equal("snapshot-2"), fmt.Sprintf("with %d workers", workers))
+			}
+		})
+
+		It("should inspect each image digest only once per run", func() {
+			builder := setupVersionCandidateTest(kornInstance, &mockGitClientWithVersions{})
+			builder.WithRuntimeObjects(createSnapshotsForConcurrency()...)
+			kornInstance.KubeClient = builder.Build()
+			podClient := &mockImageClientCounter{inspections: map[string]int{}}
+			kornInstance.PodClient = podClient
+			kornInstance.Workers = 4
+
+			result, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no new valid snapshot candidates found"))
+			Expect(result).To(BeNil())
+			Expect(podClient.inspections).To(Equal(map[string]int{
+				"registry.test.com/controller@sha256:abc123": 1,
+				testContainerImage:                           1,
+			}))
+		})
+	})
+
 	Context("Edge cases", func() {
 		It("should return true when only bundle component exists (empty component list)", func() {
 			// Create a valid snapshot
@@ -1027,6 +1207,39 @@ func (m *mockGitClientWithVersions) GetVersion(repoURL, commitHash string) (*sem
 	return &version, nil
 }
 
+func (m *mockGitClientWithVersions) GetCommitTitle(repoURL, commitHash string) (string, error) {
+	return "Commit " + commitHash, nil
+}
+
 func (m *mockGitClientWithVersions) Cleanup() {
 	// No cleanup needed for mock
 }
+
+// Mock git client that returns the same version or error for every repository
+type mockGitClientFixedVersion struct {
+	version  string
+	err      error
+	cleanups int
+}
+
+func (m *mockGitClientFixedVersion) GetVersion(repoURL, commitHash string) (*semver.Version, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	version, err := semver.ParseTolerant(m.version)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (m *mockGitClientFixedVersion) GetCommitTitle(repoURL, commitHash string) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	return "Commit " + commitHash, nil
+}
+
+func (m *mockGitClientFixedVersion) Cleanup() {
+	m.cleanups++
+}
@@ -0,0 +1,175 @@
+package konflux
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jordigilh/korn/internal"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// ApplicationStatus is an overview of where an application stands: the snapshots built and the releases to each of
+// its environments
+type ApplicationStatus struct {
+	Application applicationapiv1alpha1.Application
+	// LatestSnapshot is the newest push snapshot of the application, if any
+	LatestSnapshot *applicationapiv1alpha1.Snapshot
+	// Candidate is the snapshot that would be released next. CandidateError explains why there is none.
+	Candidate      *applicationapiv1alpha1.Snapshot
+	CandidateError string
+	Environments   []EnvironmentStatus
+	// PendingPromotions lists the environments that run a newer snapshot than the next one in the promotion order of
+	// the application
+	PendingPromotions []Promotion
+}
+
+// Promotion is a pair of consecutive environments in the promotion order of an application
+type Promotion struct {
+	From string
+	To   string
+}
+
+// EnvironmentStatus contains the releases of the application to one of its environments
+type EnvironmentStatus struct {
+	Environment
+	// LatestRelease is the newest release to the environment, whatever its result
+	LatestRelease *releaseapiv1alpha1.Release
+	// Deployed is the snapshot of the newest successful release to the environment, and Version the version labels of
+	// the images of its release components
+	Deployed *applicationapiv1alpha1.Snapshot
+	Version  string
+}
+
+// GetApplicationStatus returns the newest snapshot of the application, its release candidate and the latest release to
+// each environment. The absence of a candidate is not an error, since it only means that there is nothing new to
+// release. Any other error found while looking for the candidate is returned.
+func (k Korn) GetApplicationStatus() (*ApplicationStatus, error) {
+	app, err := k.GetApplication()
+	if err != nil {
+		return nil, err
+	}
+	status := &ApplicationStatus{Application: *app}
+	// Inspect each image only once, regardless of how many snapshots reference it
+	k.PodClient = internal.NewMemoizedImageClient(k.PodClient)
+
+	snapshots, err := k.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) > 0 {
+		status.LatestSnapshot = &snapshots[0]
+	}
+	status.Candidate, err = k.GetSnapshotCandidateForRelease()
+	switch {
+	case errors.Is(err, ErrNoSnapshotCandidate):
+		status.CandidateError = err.Error()
+	case err != nil:
+		return nil, fmt.Errorf("unable to find the release candidate of application %s/%s: %w", k.Namespace, k.ApplicationName, err)
+	}
+
+	envs, err := k.ListEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	releases, err := k.ListReleases()
+	if err != nil {
+		return nil, err
+	}
+	comps, err := k.getComponentsForRelease()
+	if err != nil {
+		return nil, err
+	}
+	for _, env := range envs {
+		s := EnvironmentStatus{Environment: env}
+		for i, r := range releases {
+			if r.Spec.ReleasePlan != env.ReleasePlan.Name {
+				continue
+			}
+			if s.LatestRelease == nil {
+				s.LatestRelease = &releases[i]
+			}
+			if isReleaseSuccessful(r) {
+				snapshot, err := k.getSnapshotByName(r.Spec.Snapshot)
+				if err != nil {
+					logrus.Warnf("unable to get snapshot %s of release %s: %v", r.Spec.Snapshot, r.Name, err)
+					break
+				}
+				s.Deployed = snapshot
+				s.Version = k.snapshotVersion(*snapshot, comps)
+				break
+			}
+		}
+		status.Environments = append(status.Environments, s)
+	}
+	status.PendingPromotions = pendingPromotions(GetPromotionOrder(*app), status.Environments)
+	return status, nil
+}
+
+// ReleaseResult returns the reason of the release's Released condition, such as Succeeded, Failed or Progressing
+func ReleaseResult(release releaseapiv1alpha1.Release) string {
+	if c := getConditionByType("Released", release.Status.Conditions); c != nil {
+		return c.Reason
+	}
+	return ""
+}
+
+func isReleaseSuccessful(release releaseapiv1alpha1.Release) bool {
+	return ReleaseResult(release) == "Succeeded"
+}
+
+func (k Korn) getSnapshotByName(name string) (*applicationapiv1alpha1.Snapshot, error) {
+	k.SnapshotName = name
+	k.SHA = ""
+	return k.GetSnapshot()
+}
+
+// snapshotVersion returns the distinct version labels of the images of the components released from the snapshot.
+// Images that can't be inspected, like Helm charts, are skipped.
+func (k Korn) snapshotVersion(snapshot applicationapiv1alpha1.Snapshot, comps []applicationapiv1alpha1.Component) string {
+	versions := []string{}
+	for _, c := range comps {
+		spec, err := GetComponentPullspecFromSnapshot(snapshot, c.Name)
+		if err != nil {
+			continue
+		}
+		data, err := k.PodClient.GetImageData(spec)
+		if err != nil {
+			logrus.Debugf("unable to inspect image %s of component %s: %v", spec, c.Name, err)
+			continue
+		}
+		if v := data.Labels[versionImageLabel]; len(v) > 0 && !slices.Contains(versions, v) {
+			versions = append(versions, v)
+		}
+	}
+	return strings.Join(versions, ", ")
+}
+
+// pendingPromotions returns the consecutive environments in the promotion order where the first runs a newer snapshot
+// than the next one, or the next one has nothing deployed yet. Environments without a release plan are skipped.
+func pendingPromotions(order []string, envs []EnvironmentStatus) []Promotion {
+	deployed := map[string]*applicationapiv1alpha1.Snapshot{}
+	for _, e := range envs {
+		if _, ok := deployed[e.Name]; !ok {
+			deployed[e.Name] = e.Deployed
+		}
+	}
+	present := slices.DeleteFunc(slices.Clone(order), func(name string) bool {
+		_, ok := deployed[name]
+		return !ok
+	})
+	promotions := []Promotion{}
+	for i := 0; i+1 < len(present); i++ {
+		from, to := deployed[present[i]], deployed[present[i+1]]
+		if from == nil {
+			continue
+		}
+		if to == nil || (from.Name != to.Name && to.CreationTimestamp.Before(&from.CreationTimestamp)) {
+			promotions = append(promotions, Promotion{From: present[i], To: present[i+1]})
+		}
+	}
+	return promotions
+}
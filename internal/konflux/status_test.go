@@ -0,0 +1,158 @@
+package konflux_test
+
+import (
+	"time"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Application status", func() {
+	var kornInstance *konflux.Korn
+
+	snapshotAt := func(name string, hoursAgo int) *applicationapiv1alpha1.Snapshot {
+		s := newFinishedSnapshot(name, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		s.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(-hoursAgo) * time.Hour))
+		return s
+	}
+
+	releaseAt := func(name, snapshot, rp string, hoursAgo int, succeeded bool) *releaseapiv1alpha1.Release {
+		r := testutils.NewSuccessfulRelease(name, testutils.TestNamespace, snapshot, rp, testutils.TestAppName, testutils.BundleComponentName)
+		if !succeeded {
+			r = testutils.NewFailedRelease(name, testutils.TestNamespace, snapshot, rp, testutils.TestAppName, testutils.BundleComponentName)
+		}
+		r.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(-hoursAgo) * time.Hour))
+		return r
+	}
+
+	buildClientWithApplication := func(app *applicationapiv1alpha1.Application, objs ...runtime.Object) {
+		kornInstance.KubeClient = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			append([]runtime.Object{
+				newNamespace(testutils.TestNamespace),
+				app,
+				testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+				testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+				testutils.NewReleasePlan("staging-rp", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.EnvironmentLabel: "staging"}),
+				testutils.NewReleasePlan("production-rp", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.EnvironmentLabel: "production"}),
+			}, objs...)...,
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName).Build()
+	}
+
+	buildClient := func(objs ...runtime.Object) {
+		buildClientWithApplication(testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace), objs...)
+	}
+
+	environment := func(status *konflux.ApplicationStatus, name string) konflux.EnvironmentStatus {
+		for _, e := range status.Environments {
+			if e.Name == name {
+				return e
+			}
+		}
+		Fail("environment " + name + " not found")
+		return konflux.EnvironmentStatus{}
+	}
+
+	BeforeEach(func() {
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			PodClient:       &mockImageClientWithVersion{version: "1.0.1"},
+		}
+	})
+
+	It("should show the snapshots and the releases to each environment", func() {
+		buildClient(
+			snapshotAt("old-snapshot", 48),
+			snapshotAt("released-snapshot", 24),
+			snapshotAt("new-snapshot", 1),
+			releaseAt("production-release", "old-snapshot", "production-rp", 40, true),
+			releaseAt("staging-release", "released-snapshot", "staging-rp", 20, true),
+			releaseAt("failed-production-release", "released-snapshot", "production-rp", 10, false),
+		)
+
+		status, err := kornInstance.GetApplicationStatus()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Application.Name).To(Equal(testutils.TestAppName))
+		Expect(status.LatestSnapshot.Name).To(Equal("new-snapshot"))
+		Expect(status.Candidate.Name).To(Equal("new-snapshot"))
+		Expect(status.CandidateError).To(BeEmpty())
+
+		staging := environment(status, "staging")
+		Expect(staging.LatestRelease.Name).To(Equal("staging-release"))
+		Expect(staging.Deployed.Name).To(Equal("released-snapshot"))
+		Expect(staging.Version).To(Equal("1.0.1"))
+
+		production := environment(status, "production")
+		Expect(production.LatestRelease.Name).To(Equal("failed-production-release"))
+		Expect(konflux.ReleaseResult(*production.LatestRelease)).To(Equal("Failed"))
+		Expect(production.Deployed.Name).To(Equal("old-snapshot"))
+		Expect(status.PendingPromotions).To(ConsistOf(konflux.Promotion{From: "staging", To: "production"}))
+	})
+
+	It("should report why there is no candidate and that production is up to date", func() {
+		buildClient(
+			snapshotAt("released-snapshot", 24),
+			releaseAt("staging-release", "released-snapshot", "staging-rp", 20, true),
+			releaseAt("production-release", "released-snapshot", "production-rp", 10, true),
+		)
+
+		status, err := kornInstance.GetApplicationStatus()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Candidate).To(BeNil())
+		Expect(status.CandidateError).To(ContainSubstring("no new valid snapshot candidates found"))
+		Expect(environment(status, "production").Deployed.Name).To(Equal("released-snapshot"))
+		Expect(status.PendingPromotions).To(BeEmpty())
+	})
+
+	It("should show the environments without releases", func() {
+		buildClient()
+
+		status, err := kornInstance.GetApplicationStatus()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.LatestSnapshot).To(BeNil())
+		Expect(status.Environments).To(HaveLen(2))
+		for _, e := range status.Environments {
+			Expect(e.LatestRelease).To(BeNil())
+			Expect(e.Deployed).To(BeNil())
+		}
+		Expect(status.PendingPromotions).To(BeEmpty())
+	})
+
+	It("should follow the promotion order of the application", func() {
+		app := testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace)
+		app.Annotations = map[string]string{konflux.PromotionOrderAnnotation: "development, staging,production"}
+		buildClientWithApplication(app,
+			testutils.NewReleasePlan("development-rp", testutils.TestNamespace, testutils.TestAppName, map[string]string{konflux.EnvironmentLabel: "development"}),
+			snapshotAt("old-snapshot", 48),
+			snapshotAt("new-snapshot", 1),
+			releaseAt("development-release", "new-snapshot", "development-rp", 1, true),
+			releaseAt("staging-release", "old-snapshot", "staging-rp", 40, true),
+			releaseAt("production-release", "old-snapshot", "production-rp", 30, true),
+		)
+
+		status, err := kornInstance.GetApplicationStatus()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.PendingPromotions).To(ConsistOf(konflux.Promotion{From: "development", To: "staging"}))
+	})
+
+	It("should return the errors found while looking for the candidate", func() {
+		buildClient(snapshotAt("new-snapshot", 1))
+		kornInstance.PodClient = &mockImageClientError{}
+
+		_, err := kornInstance.GetApplicationStatus()
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to find the release candidate of application test-namespace/test-app"))
+	})
+})
@@ -0,0 +1,197 @@
+package konflux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// streamRuntimeAnnotations are set by kubectl and the Konflux controllers on the resources of an existing stream and
+// don't apply to the resources of a new one
+var streamRuntimeAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"build.appstudio.openshift.io/status",
+	"image.redhat.com/image",
+}
+
+// StreamManifests contains the resources of a new version stream of an application
+type StreamManifests struct {
+	Application  applicationapiv1alpha1.Application
+	Components   []applicationapiv1alpha1.Component
+	ReleasePlans []releaseapiv1alpha1.ReleasePlan
+}
+
+// Objects returns the resources in the order they must be created
+func (m StreamManifests) Objects() []client.Object {
+	objs := []client.Object{&m.Application}
+	for i := range m.Components {
+		objs = append(objs, &m.Components[i])
+	}
+	for i := range m.ReleasePlans {
+		objs = append(objs, &m.ReleasePlans[i])
+	}
+	return objs
+}
+
+// GenerateStreamManifests returns the application k.StreamName with the components and release plans of the
+// application k.ApplicationName, built from the git branch k.Branch. The korn labels are preserved and the names of
+// the resources are derived from the one of the new application. The components don't keep the image repository of
+// the source ones, so that the image controller provisions a new one for each of them. When the source application belongs to an operator
+// stream, k.Version is the version of the new one.
+func (k Korn) GenerateStreamManifests() (*StreamManifests, error) {
+	if len(k.StreamName) == 0 {
+		return nil, errors.New("name of the new application is required")
+	}
+	if len(k.Branch) == 0 {
+		return nil, errors.New("git branch of the new application is required")
+	}
+	from, err := k.GetApplication()
+	if err != nil {
+		return nil, err
+	}
+	err = k.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: k.Namespace, Name: k.StreamName}, &applicationapiv1alpha1.Application{})
+	if err == nil {
+		return nil, fmt.Errorf("application %s already exists in namespace %s", k.StreamName, k.Namespace)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	rename := streamRenamer(from.Name, k.StreamName)
+
+	app := applicationapiv1alpha1.Application{
+		TypeMeta:   metav1.TypeMeta{Kind: "Application", APIVersion: applicationapiv1alpha1.GroupVersion.String()},
+		ObjectMeta: streamObjectMeta(from.ObjectMeta, k.StreamName),
+		Spec:       *from.Spec.DeepCopy(),
+	}
+	app.Spec.DisplayName = k.StreamName
+	app.Annotations[BranchAnnotation] = k.Branch
+	if _, ok := from.Labels[StreamLabel]; ok {
+		if len(k.Version) == 0 {
+			return nil, fmt.Errorf("version is required since application %s belongs to the stream %s", from.Name, from.Labels[StreamLabel])
+		}
+		if _, err := semver.ParseTolerant(k.Version); err != nil {
+			return nil, fmt.Errorf("invalid version %s: %v", k.Version, err)
+		}
+		app.Labels[StreamVersionLabel] = k.Version
+	}
+	m := &StreamManifests{Application: app}
+
+	comps, err := k.ListComponents()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comps {
+		name := rename(c.Name)
+		comp := applicationapiv1alpha1.Component{
+			TypeMeta:   metav1.TypeMeta{Kind: "Component", APIVersion: applicationapiv1alpha1.GroupVersion.String()},
+			ObjectMeta: streamObjectMeta(c.ObjectMeta, name),
+			Spec:       *c.Spec.DeepCopy(),
+		}
+		comp.Spec.Application = k.StreamName
+		comp.Spec.ComponentName = name
+		// Let the image controller provision the repository of the new component, instead of pushing its builds to
+		// the one of the source stream
+		comp.Spec.ContainerImage = ""
+		if refs, ok := comp.Annotations[BundleComponentsAnnotation]; ok {
+			names := strings.Split(refs, ",")
+			for i := range names {
+				names[i] = rename(strings.TrimSpace(names[i]))
+			}
+			comp.Annotations[BundleComponentsAnnotation] = strings.Join(names, ",")
+		}
+		if comp.Spec.Source.GitSource != nil {
+			comp.Spec.Source.GitSource.Revision = k.Branch
+		}
+		logrus.Debugf("component %s of application %s generated from %s", name, k.StreamName, c.Name)
+		m.Components = append(m.Components, comp)
+	}
+
+	rps, err := k.ListReleasePlans()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rps {
+		rp := releaseapiv1alpha1.ReleasePlan{
+			TypeMeta:   metav1.TypeMeta{Kind: "ReleasePlan", APIVersion: releaseapiv1alpha1.GroupVersion.String()},
+			ObjectMeta: streamObjectMeta(r.ObjectMeta, rename(r.Name)),
+			Spec:       *r.Spec.DeepCopy(),
+		}
+		rp.Spec.Application = k.StreamName
+		m.ReleasePlans = append(m.ReleasePlans, rp)
+	}
+	return m, nil
+}
+
+// CreateStream creates the resources of the new stream. The ReleasePlanAdmissions are not modified, since they are
+// owned by the managed namespace, so a warning is logged for each target that must admit the new application.
+func (k Korn) CreateStream(m StreamManifests) error {
+	opts := client.CreateOptions{}
+	if k.DryRun {
+		opts.DryRun = append(opts.DryRun, "all")
+	}
+	for _, obj := range m.Objects() {
+		if err := k.KubeClient.Create(context.Background(), obj, &opts); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("%s %s already exists in namespace %s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), obj.GetNamespace())
+			}
+			return err
+		}
+		logrus.Debugf("%s %s created", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+	}
+	for _, rp := range m.ReleasePlans {
+		logrus.Warnf("the ReleasePlanAdmission of namespace %s must include application %s for release plan %s to be admitted", rp.Spec.Target, m.Application.Name, rp.Name)
+	}
+	return nil
+}
+
+// streamObjectMeta returns a copy of the metadata with the new name, without the fields set by the API server and the
+// runtime annotations of the source resource
+func streamObjectMeta(meta metav1.ObjectMeta, name string) metav1.ObjectMeta {
+	labels := map[string]string{}
+	maps.Copy(labels, meta.Labels)
+	annotations := map[string]string{}
+	maps.Copy(annotations, meta.Annotations)
+	for _, a := range streamRuntimeAnnotations {
+		delete(annotations, a)
+	}
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   meta.Namespace,
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
+
+// streamRenamer returns a function that derives the name of a resource of the new stream from the one in the source
+// stream. Names that contain the source application have it replaced by the new one. Otherwise, the trailing part
+// that differs between both application names, starting from a dash, is replaced, so operator-staging-1-0 becomes
+// operator-staging-1-1 when going from operator-1-0 to operator-1-1. The new application name is appended to any
+// other name.
+func streamRenamer(from, to string) func(string) string {
+	i := 0
+	for i < len(from) && i < len(to) && from[i] == to[i] {
+		i++
+	}
+	i = strings.LastIndex(from[:i], "-")
+	return func(name string) string {
+		switch {
+		case strings.Contains(name, from):
+			return strings.ReplaceAll(name, from, to)
+		case i >= 0 && strings.HasSuffix(name, from[i:]):
+			return strings.TrimSuffix(name, from[i:]) + to[i:]
+		default:
+			return fmt.Sprintf("%s-%s", name, to)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package konflux_test
+
+import (
+	"context"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Version streams", func() {
+	var (
+		kornInstance *konflux.Korn
+		builder      *fake.ClientBuilder
+	)
+
+	BeforeEach(func() {
+		app := testutils.NewStreamApplication("operator-1-0", testutils.TestNamespace, "my-operator", "1.0")
+		app.Annotations = map[string]string{
+			konflux.BranchAnnotation:                           "release-1.0",
+			"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		}
+		app.ResourceVersion = "42"
+		controller := testutils.NewControllerComponent("controller-rhel9-operator-1-0", testutils.TestNamespace, "operator-1-0")
+		controller.Spec.Source = applicationapiv1alpha1.ComponentSource{
+			ComponentSourceUnion: applicationapiv1alpha1.ComponentSourceUnion{
+				GitSource: &applicationapiv1alpha1.GitSource{URL: "https://github.com/test/controller.git", Revision: "release-1.0"},
+			},
+		}
+		controller.Annotations = map[string]string{"build.appstudio.openshift.io/status": "{}"}
+		controller.Spec.ContainerImage = "quay.io/test/controller-operator-1-0"
+		builder = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			newNamespace(testutils.TestNamespace),
+			app,
+			controller,
+			testutils.NewBundleComponent("bundle", testutils.TestNamespace, "operator-1-0"),
+			testutils.NewBundleComponent("other-bundle", testutils.TestNamespace, testutils.OtherAppName),
+			testutils.NewStagingReleasePlan("operator-staging-1-0", testutils.TestNamespace, "operator-1-0"),
+			testutils.NewReleasePlan("operator-1-0-production", testutils.TestNamespace, "operator-1-0", map[string]string{konflux.EnvironmentLabel: "production"}),
+		)
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: "operator-1-0",
+			StreamName:      "operator-1-1",
+			Branch:          "release-1.1",
+			Version:         "1.1",
+			KubeClient:      builder.Build(),
+		}
+	})
+
+	It("should copy the application, its components and release plans", func() {
+		m, err := kornInstance.GenerateStreamManifests()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(m.Application.Name).To(Equal("operator-1-1"))
+		Expect(m.Application.ResourceVersion).To(BeEmpty())
+		Expect(m.Application.Spec.DisplayName).To(Equal("operator-1-1"))
+		Expect(m.Application.Labels).To(HaveKeyWithValue(konflux.StreamLabel, "my-operator"))
+		Expect(m.Application.Labels).To(HaveKeyWithValue(konflux.StreamVersionLabel, "1.1"))
+		Expect(m.Application.Labels).To(HaveKeyWithValue(konflux.ApplicationTypeLabel, "operator"))
+		Expect(m.Application.Annotations).To(Equal(map[string]string{konflux.BranchAnnotation: "release-1.1"}))
+
+		Expect(m.Components).To(HaveLen(2))
+		for _, c := range m.Components {
+			Expect(c.Spec.Application).To(Equal("operator-1-1"))
+			Expect(c.Spec.ComponentName).To(Equal(c.Name))
+			Expect(c.Annotations).To(BeEmpty())
+			switch c.Name {
+			case "controller-rhel9-operator-1-1":
+				Expect(c.Labels).To(HaveKeyWithValue(konflux.BundleReferenceLabel, "controller"))
+				Expect(c.Spec.Source.GitSource.URL).To(Equal("https://github.com/test/controller.git"))
+				Expect(c.Spec.Source.GitSource.Revision).To(Equal("release-1.1"))
+				Expect(c.Spec.ContainerImage).To(BeEmpty())
+			case "bundle-operator-1-1":
+				Expect(c.Labels).To(HaveKeyWithValue(konflux.ComponentTypeLabel, "bundle"))
+			default:
+				Fail("unexpected component " + c.Name)
+			}
+		}
+
+		Expect(m.ReleasePlans).To(HaveLen(2))
+		names := []string{}
+		for _, rp := range m.ReleasePlans {
+			Expect(rp.Spec.Application).To(Equal("operator-1-1"))
+			Expect(rp.Labels).To(HaveKey(konflux.EnvironmentLabel))
+			names = append(names, rp.Name)
+		}
+		Expect(names).To(ConsistOf("operator-staging-1-1", "operator-1-1-production"))
+		Expect(m.Objects()).To(HaveLen(5))
+	})
+
+	It("should rename the components referenced by the bundle", func() {
+		bundle := testutils.NewBundleComponent("bundle-1-0", testutils.TestNamespace, "operator-1-0")
+		bundle.Annotations = map[string]string{konflux.BundleComponentsAnnotation: "controller-rhel9-operator-1-0"}
+		kornInstance.KubeClient = builder.WithRuntimeObjects(bundle).Build()
+
+		m, err := kornInstance.GenerateStreamManifests()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(m.Components).To(ContainElement(HaveField("ObjectMeta.Annotations",
+			HaveKeyWithValue(konflux.BundleComponentsAnnotation, "controller-rhel9-operator-1-1"))))
+	})
+
+	It("should fail when the existence of the new application can't be checked", func() {
+		kornInstance.KubeClient = builder.WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if key.Name == "operator-1-1" {
+					return apierrors.NewForbidden(schema.GroupResource{Group: "appstudio.redhat.com", Resource: "applications"}, key.Name, nil)
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build()
+
+		_, err := kornInstance.GenerateStreamManifests()
+
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+	})
+
+	It("should create the resources of the stream", func() {
+		m, err := kornInstance.GenerateStreamManifests()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(kornInstance.CreateStream(*m)).To(Succeed())
+
+		kornInstance.ApplicationName = "operator-1-1"
+		app, err := kornInstance.GetApplication()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(app.Annotations).To(HaveKeyWithValue(konflux.BranchAnnotation, "release-1.1"))
+		comps, err := kornInstance.ListComponents()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(comps).To(HaveLen(2))
+		rps, err := kornInstance.ListReleasePlans()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rps).To(HaveLen(2))
+	})
+
+	DescribeTable("should fail to generate the stream",
+		func(update func(k *konflux.Korn), expected string) {
+			update(kornInstance)
+
+			_, err := kornInstance.GenerateStreamManifests()
+
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+		Entry("without a name", func(k *konflux.Korn) { k.StreamName = "" }, "name of the new application is required"),
+		Entry("without a branch", func(k *konflux.Korn) { k.Branch = "" }, "git branch of the new application is required"),
+		Entry("when the source application doesn't exist", func(k *konflux.Korn) { k.ApplicationName = "operator-0-9" }, "application operator-0-9 not found"),
+		Entry("when the new application already exists", func(k *konflux.Korn) { k.StreamName = "operator-1-0" }, "application operator-1-0 already exists"),
+		Entry("without the version of a stream", func(k *konflux.Korn) { k.Version = "" }, "version is required"),
+		Entry("with an invalid version", func(k *konflux.Korn) { k.Version = "one" }, "invalid version one"),
+	)
+})
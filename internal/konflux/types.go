@@ -1,6 +1,8 @@
 package konflux
 
 import (
+	"time"
+
 	"github.com/jordigilh/korn/internal"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,8 +28,52 @@ type Korn struct {
 	GitClient       internal.GitCommitVersioner
 	DynamicClient   dynamic.Interface
 	Candidate       bool
+	// Workers is the maximum number of snapshots that are validated concurrently when looking for a release candidate
+	Workers int
+	// RecordVerdicts enables storing the candidacy verdict of each validated snapshot in its annotations
+	RecordVerdicts bool
+	// TrustVerdictsFor is how long a verdict recorded in a snapshot is reused instead of validating the snapshot again
+	TrustVerdictsFor time.Duration
+	// KornVersion is the version of the CLI, recorded together with the verdicts
+	KornVersion string
+	// BaseSnapshotName is the snapshot used as the base when creating an override snapshot or comparing snapshots
+	BaseSnapshotName string
+	// ComponentOverrides maps the name of each component to the container image that replaces it in an override snapshot
+	ComponentOverrides map[string]string
+	// AgainstLastRelease compares a snapshot with the one used in the last successful release of the application
+	AgainstLastRelease bool
+	// ApprovalReason is recorded in the snapshot when it is manually approved or rejected
+	ApprovalReason string
+	// RequireApproval restricts the release candidates to the snapshots that have been manually approved
+	RequireApproval bool
+	// Branch restricts the release candidates to the snapshots built from this git branch
+	Branch string
+	// Operator is the value of the korn.redhat.io/stream label used to resolve the application of a version stream
+	Operator string
+	// StreamName is the name of the application created for a new version stream
+	StreamName string
+	// ReleasePlanAdmissionName is the name of a ReleasePlanAdmission, optionally prefixed with its namespace
+	ReleasePlanAdmissionName string
+	// CompareReleaseLabel enables the comparison of the release label between the bundle and the component images
+	CompareReleaseLabel bool
+	// WaitForRetest keeps waiting for the snapshot of a commit after its integration tests fail, until it is retested or
+	// the commit is rebuilt successfully or the timeout is reached
+	WaitForRetest bool
+	// verdictOptions is the fingerprint of the options the snapshots are validated with, recorded with their verdicts
+	verdictOptions string
+}
+
+// ComponentVersion contains the version and release labels of a component's container image in a snapshot
+type ComponentVersion struct {
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+	Version string `json:"version"`
+	Release string `json:"release,omitempty"`
 }
 
+// DefaultWorkers is the default number of snapshots validated concurrently when looking for a release candidate
+const DefaultWorkers = 4
+
 type ReleaseNote struct {
 	Type       releaseType         `json:"type" yaml:"type"`
 	Issues     map[string]any      `json:"issues,omitempty" yaml:"issues,omitempty"`
@@ -0,0 +1,92 @@
+package konflux
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blang/semver/v4"
+	ptypes "github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/jordigilh/korn/internal"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	versionImageLabel = "version"
+	releaseImageLabel = "release"
+)
+
+// ValidationRule identifies each of the rules a snapshot must satisfy to be a candidate for release
+type ValidationRule string
+
+const (
+	TestsSucceededRule     ValidationRule = "tests-succeeded"
+	ApprovalRule           ValidationRule = "approval"
+	BundleReferenceRule    ValidationRule = "bundle-reference"
+	VersionConsistencyRule ValidationRule = "version-consistency"
+	ReleaseConsistencyRule ValidationRule = "release-consistency"
+	SourceVersionRule      ValidationRule = "source-version"
+	ChartReferenceRule     ValidationRule = "chart-reference"
+)
+
+// Verdict is the outcome of validating a snapshot as a candidate for release
+type Verdict struct {
+	Valid bool
+	// FailedRule is the first rule the snapshot did not satisfy, if any
+	FailedRule ValidationRule
+	Message    string
+	// KornVersion, Timestamp and Options are only populated for verdicts recorded in the snapshot
+	KornVersion string
+	Timestamp   time.Time
+	// Options is the fingerprint of the validation options
+	Options string
+}
+
+// rejectSnapshot logs the reason why a snapshot is not a candidate for release and returns its verdict
+func rejectSnapshot(rule ValidationRule, format string, args ...any) Verdict {
+	msg := fmt.Sprintf(format, args...)
+	logrus.Info(msg)
+	return Verdict{FailedRule: rule, Message: msg}
+}
+
+// validateSourceVersions cross-checks the version label of each component image in the snapshot against the version
+// stored in the VERSION.txt file of the component's git repository at the revision used to build it.
+// Components without a git source or whose repository does not contain a version file are not checked. The images map
+// contains the image data already inspected for the snapshot, indexed by component name.
+func (k Korn) validateSourceVersions(snapshot applicationapiv1alpha1.Snapshot, images map[string]*ptypes.ImageInspectReport) (Verdict, error) {
+	for _, c := range snapshot.Spec.Components {
+		if c.Source.GitSource == nil {
+			logrus.Debugf("git source reference for component %s is missing, skipping source version check", c.Name)
+			continue
+		}
+		srcVersion, err := k.GitClient.GetVersion(c.Source.GitSource.URL, c.Source.GitSource.Revision)
+		if err != nil {
+			if errors.Is(err, internal.ErrVersionFileNotFound) {
+				logrus.Debugf("%s, skipping source version check for component %s", err, c.Name)
+				continue
+			}
+			return Verdict{}, fmt.Errorf("failed to fetch source version for component %s in snapshot %s: %v", c.Name, snapshot.Name, err)
+		}
+		imgData, ok := images[c.Name]
+		if !ok {
+			imgData, err = k.PodClient.GetImageData(c.ContainerImage)
+			if err != nil {
+				return Verdict{}, err
+			}
+		}
+		label, ok := imgData.Labels[versionImageLabel]
+		if !ok {
+			logrus.Debugf("label %s not found in container image %s, skipping source version check for component %s", versionImageLabel, c.ContainerImage, c.Name)
+			continue
+		}
+		imgVersion, err := semver.ParseTolerant(label)
+		if err != nil {
+			return rejectSnapshot(SourceVersionRule, "component %s has an invalid %s label %q in container image %s, snapshot is not a candidate for release", c.Name, versionImageLabel, label, c.ContainerImage), nil
+		}
+		if !imgVersion.Equals(*srcVersion) {
+			return rejectSnapshot(SourceVersionRule, "component %s version drift in snapshot %s: image label has %s and source %s at %s has %s", c.Name, snapshot.Name, imgVersion, c.Source.GitSource.URL, c.Source.GitSource.Revision, srcVersion), nil
+		}
+	}
+	return Verdict{Valid: true}, nil
+}
@@ -0,0 +1,178 @@
+package konflux
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	VerdictAnnotation            = "korn.redhat.io/verdict"
+	VerdictRuleAnnotation        = "korn.redhat.io/verdict-rule"
+	VerdictMessageAnnotation     = "korn.redhat.io/verdict-message"
+	VerdictKornVersionAnnotation = "korn.redhat.io/verdict-korn-version"
+	VerdictTimestampAnnotation   = "korn.redhat.io/verdict-timestamp"
+	// VerdictOptionsAnnotation contains the fingerprint of the options the snapshot was validated with
+	VerdictOptionsAnnotation = "korn.redhat.io/verdict-options"
+
+	validVerdict   = "valid"
+	invalidVerdict = "invalid"
+	// devKornVersion is the version of the builds of korn without a release version, whose rules can change between
+	// builds
+	devKornVersion = "dev"
+)
+
+// GetRecordedVerdict returns the candidacy verdict recorded in the snapshot annotations by a previous korn run
+func GetRecordedVerdict(snapshot applicationapiv1alpha1.Snapshot) (*Verdict, bool) {
+	result, ok := snapshot.Annotations[VerdictAnnotation]
+	if !ok || (result != validVerdict && result != invalidVerdict) {
+		return nil, false
+	}
+	ts, err := time.Parse(time.RFC3339, snapshot.Annotations[VerdictTimestampAnnotation])
+	if err != nil {
+		logrus.Debugf("invalid verdict timestamp in snapshot %s: %v", snapshot.Name, err)
+		return nil, false
+	}
+	return &Verdict{
+		Valid:       result == validVerdict,
+		FailedRule:  ValidationRule(snapshot.Annotations[VerdictRuleAnnotation]),
+		Message:     snapshot.Annotations[VerdictMessageAnnotation],
+		KornVersion: snapshot.Annotations[VerdictKornVersionAnnotation],
+		Timestamp:   ts,
+		Options:     snapshot.Annotations[VerdictOptionsAnnotation],
+	}, true
+}
+
+// VerdictStatus returns the result of the verdict and the rule that failed, if any
+func VerdictStatus(v Verdict) string {
+	if v.Valid {
+		return "Valid"
+	}
+	return fmt.Sprintf("Invalid (%s)", v.FailedRule)
+}
+
+// SnapshotVerdictStatus returns the result of the verdict recorded in the snapshot, or an empty string if there is none
+func SnapshotVerdictStatus(snapshot applicationapiv1alpha1.Snapshot) string {
+	v, ok := GetRecordedVerdict(snapshot)
+	if !ok {
+		return ""
+	}
+	return VerdictStatus(*v)
+}
+
+// SnapshotVerdictStatusDetails returns the verdict recorded in the snapshot with the version of korn that recorded it
+// when valid, or the rule that failed and its message otherwise
+func SnapshotVerdictStatusDetails(snapshot applicationapiv1alpha1.Snapshot) string {
+	v, ok := GetRecordedVerdict(snapshot)
+	switch {
+	case !ok:
+		return ""
+	case v.Valid:
+		return fmt.Sprintf("Valid (korn %s)", v.KornVersion)
+	}
+	return fmt.Sprintf("Invalid (%s): %s", v.FailedRule, v.Message)
+}
+
+// getTrustedVerdict returns the verdict recorded in the snapshot when it was recorded by the same released version of
+// korn, with the same validation options and within the period defined by k.TrustVerdictsFor
+func (k Korn) getTrustedVerdict(snapshot applicationapiv1alpha1.Snapshot) (*Verdict, bool) {
+	if k.TrustVerdictsFor <= 0 {
+		return nil, false
+	}
+	v, ok := GetRecordedVerdict(snapshot)
+	if !ok {
+		return nil, false
+	}
+	if v.KornVersion != k.KornVersion || len(v.KornVersion) == 0 || v.KornVersion == devKornVersion {
+		logrus.Debugf("ignoring verdict in snapshot %s recorded by korn %s", snapshot.Name, v.KornVersion)
+		return nil, false
+	}
+	if v.Options != k.verdictOptions {
+		logrus.Debugf("ignoring verdict in snapshot %s recorded with other validation options", snapshot.Name)
+		return nil, false
+	}
+	if time.Since(v.Timestamp) > k.TrustVerdictsFor {
+		logrus.Debugf("ignoring verdict in snapshot %s recorded at %s", snapshot.Name, v.Timestamp.Format(time.RFC3339))
+		return nil, false
+	}
+	return v, true
+}
+
+// recordVerdict stores the verdict in the snapshot annotations. Verdicts of snapshots that have not completed their
+// tests or that depend on their manual approval are not recorded since their status can still change, and neither are
+// verdicts that were read from the snapshot. Failing to record the verdict is not considered an error.
+func (k Korn) recordVerdict(snapshot applicationapiv1alpha1.Snapshot, v Verdict) {
+	if v.FailedRule == TestsSucceededRule || v.FailedRule == ApprovalRule || !v.Timestamp.IsZero() {
+		return
+	}
+	s := snapshot.DeepCopy()
+	patch := client.MergeFrom(snapshot.DeepCopy())
+	if s.Annotations == nil {
+		s.Annotations = map[string]string{}
+	}
+	result := invalidVerdict
+	if v.Valid {
+		result = validVerdict
+	}
+	s.Annotations[VerdictAnnotation] = result
+	s.Annotations[VerdictRuleAnnotation] = string(v.FailedRule)
+	s.Annotations[VerdictMessageAnnotation] = v.Message
+	s.Annotations[VerdictKornVersionAnnotation] = k.KornVersion
+	s.Annotations[VerdictOptionsAnnotation] = k.verdictOptions
+	s.Annotations[VerdictTimestampAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := k.KubeClient.Patch(context.TODO(), s, patch); err != nil {
+		logrus.Warnf("unable to record verdict in snapshot %s/%s: %v", snapshot.Namespace, snapshot.Name, err)
+	}
+}
+
+// withVerdictOptions returns a copy of k with the fingerprint of the options that the validation of the snapshots
+// depends on, so that recorded verdicts are only trusted when they were validated the same way. The options are the
+// application type, the release branch, the comparison of the release labels and the korn labels and annotations of
+// the components, which define the bundle references. It expects k.Branch to be already resolved.
+func (k Korn) withVerdictOptions() (Korn, error) {
+	if !k.RecordVerdicts && k.TrustVerdictsFor <= 0 {
+		return k, nil
+	}
+	appType, err := k.GetApplicationType()
+	if err != nil {
+		return k, err
+	}
+	comps, err := k.ListComponents()
+	if err != nil {
+		return k, err
+	}
+	options := []string{
+		"type=" + appType,
+		"branch=" + k.Branch,
+		fmt.Sprintf("compare-release=%t", k.CompareReleaseLabel),
+	}
+	for _, c := range comps {
+		config := map[string]string{}
+		for key, v := range c.Labels {
+			if strings.HasPrefix(key, "korn.redhat.io/") {
+				config[key] = v
+			}
+		}
+		for key, v := range c.Annotations {
+			if strings.HasPrefix(key, "korn.redhat.io/") {
+				config[key] = v
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(config)) {
+			options = append(options, fmt.Sprintf("component=%s,%s=%s", c.Name, key, config[key]))
+		}
+	}
+	slices.Sort(options)
+	sum := sha256.Sum256([]byte(strings.Join(options, "\n")))
+	k.verdictOptions = hex.EncodeToString(sum[:])[:16]
+	return k, nil
+}
@@ -0,0 +1,188 @@
+package konflux_test
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/jordigilh/korn/internal"
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const verdictSnapshotName = "verdict-snapshot"
+
+var _ = Describe("Snapshot verdicts", func() {
+	var (
+		kornInstance *konflux.Korn
+		builder      *fake.ClientBuilder
+		snapshot     *applicationapiv1alpha1.Snapshot
+	)
+
+	BeforeEach(func() {
+		snapshot = newFinishedSnapshot(verdictSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		builder = fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+			newNamespace(testutils.TestNamespace),
+			testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+			testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+			testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+		).WithIndex(&applicationapiv1alpha1.Snapshot{}, "metadata.name", filterBySnapshotName)
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			KornVersion:     "v1.2.3",
+		}
+	})
+
+	getStoredSnapshot := func() *applicationapiv1alpha1.Snapshot {
+		s := &applicationapiv1alpha1.Snapshot{}
+		Expect(kornInstance.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testutils.TestNamespace, Name: verdictSnapshotName}, s)).To(Succeed())
+		return s
+	}
+
+	Context("Recording verdicts", func() {
+		DescribeTable("should record the verdict in the snapshot annotations",
+			func(record bool, podClient internal.ImageClient, expected map[string]string) {
+				kornInstance.KubeClient = builder.WithRuntimeObjects(snapshot).Build()
+				kornInstance.PodClient = podClient
+				kornInstance.RecordVerdicts = record
+
+				_, _ = kornInstance.GetSnapshotCandidateForRelease()
+
+				annotations := getStoredSnapshot().Annotations
+				if expected == nil {
+					Expect(annotations).ToNot(HaveKey(konflux.VerdictAnnotation))
+					return
+				}
+				for k, v := range expected {
+					Expect(annotations).To(HaveKeyWithValue(k, v))
+				}
+				Expect(annotations).To(HaveKey(konflux.VerdictTimestampAnnotation))
+			},
+			Entry("when the snapshot is a valid candidate", true, &mockImageClientValid{}, map[string]string{
+				konflux.VerdictAnnotation:            "valid",
+				konflux.VerdictRuleAnnotation:        "",
+				konflux.VerdictKornVersionAnnotation: "v1.2.3",
+			}),
+			Entry("when the snapshot fails a validation rule", true, &mockImageClientVersionMismatch{}, map[string]string{
+				konflux.VerdictAnnotation:            "invalid",
+				konflux.VerdictRuleAnnotation:        string(konflux.VersionConsistencyRule),
+				konflux.VerdictKornVersionAnnotation: "v1.2.3",
+			}),
+			Entry("when recording is disabled", false, &mockImageClientValid{}, nil),
+		)
+
+		It("should not record the verdict of snapshots whose tests have not finished", func() {
+			snapshot.Status.Conditions[0].Reason = "InProgress"
+			kornInstance.KubeClient = builder.WithRuntimeObjects(snapshot).Build()
+			kornInstance.PodClient = &mockImageClientValid{}
+			kornInstance.RecordVerdicts = true
+
+			_, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).To(HaveOccurred())
+			Expect(getStoredSnapshot().Annotations).ToNot(HaveKey(konflux.VerdictAnnotation))
+		})
+	})
+
+	Context("Trusting verdicts", func() {
+		// recordVerdict validates the snapshot to record its verdict and then applies the changes to its annotations
+		recordVerdict := func(podClient internal.ImageClient, changes map[string]string) {
+			kornInstance.KubeClient = builder.WithRuntimeObjects(snapshot).Build()
+			kornInstance.PodClient = podClient
+			kornInstance.RecordVerdicts = true
+			_, _ = kornInstance.GetSnapshotCandidateForRelease()
+			kornInstance.RecordVerdicts = false
+			stored := getStoredSnapshot()
+			Expect(stored.Annotations).To(HaveKey(konflux.VerdictOptionsAnnotation))
+			maps.Copy(stored.Annotations, changes)
+			Expect(kornInstance.KubeClient.Update(context.TODO(), stored)).To(Succeed())
+		}
+
+		DescribeTable("should only reuse recent verdicts recorded by the same korn version with the same options",
+			func(podClient internal.ImageClient, changes map[string]string, mutate func(), expectCandidate bool) {
+				recordVerdict(podClient, changes)
+				if mutate != nil {
+					mutate()
+				}
+				// Any image inspection fails, so the snapshot can only be a candidate when the verdict is trusted
+				kornInstance.PodClient = &mockImageClientError{}
+				kornInstance.TrustVerdictsFor = 24 * time.Hour
+
+				candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+				if expectCandidate {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(candidate.Name).To(Equal(verdictSnapshotName))
+				} else {
+					Expect(err).To(HaveOccurred())
+					Expect(candidate).To(BeNil())
+				}
+			},
+			Entry("with a recent valid verdict", &mockImageClientValid{}, nil, nil, true),
+			Entry("with a recent invalid verdict", &mockImageClientVersionMismatch{}, nil, nil, false),
+			Entry("with a stale valid verdict", &mockImageClientValid{}, map[string]string{
+				konflux.VerdictTimestampAnnotation: time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339),
+			}, nil, false),
+			Entry("with a valid verdict from another korn version", &mockImageClientValid{}, map[string]string{
+				konflux.VerdictKornVersionAnnotation: "v1.0.0",
+			}, nil, false),
+			Entry("with a valid verdict without validation options", &mockImageClientValid{}, map[string]string{
+				konflux.VerdictOptionsAnnotation: "",
+			}, nil, false),
+			Entry("with a valid verdict recorded with other validation options", &mockImageClientValid{}, nil,
+				func() { kornInstance.CompareReleaseLabel = true }, false),
+			Entry("with a valid verdict recorded by a development build", &mockImageClientValid{}, map[string]string{
+				konflux.VerdictKornVersionAnnotation: "dev",
+			}, func() { kornInstance.KornVersion = "dev" }, false),
+		)
+
+		It("should ignore recorded verdicts when trusting is disabled", func() {
+			recordVerdict(&mockImageClientValid{}, nil)
+			kornInstance.PodClient = &mockImageClientError{}
+
+			candidate, err := kornInstance.GetSnapshotCandidateForRelease()
+
+			Expect(err).To(HaveOccurred())
+			Expect(candidate).To(BeNil())
+		})
+	})
+
+	Context("GetRecordedVerdict functionality", func() {
+		DescribeTable("should parse the verdict annotations",
+			func(annotations map[string]string, expectedFound, expectedValid bool) {
+				snapshot.Annotations = annotations
+
+				v, found := konflux.GetRecordedVerdict(*snapshot)
+
+				Expect(found).To(Equal(expectedFound))
+				if found {
+					Expect(v.Valid).To(Equal(expectedValid))
+				}
+			},
+			Entry("without annotations", nil, false, false),
+			Entry("with a valid verdict", map[string]string{
+				konflux.VerdictAnnotation:          "valid",
+				konflux.VerdictTimestampAnnotation: "2025-01-01T00:00:00Z",
+			}, true, true),
+			Entry("with an invalid verdict", map[string]string{
+				konflux.VerdictAnnotation:          "invalid",
+				konflux.VerdictRuleAnnotation:      "bundle-reference",
+				konflux.VerdictTimestampAnnotation: "2025-01-01T00:00:00Z",
+			}, true, false),
+			Entry("with an unknown verdict", map[string]string{
+				konflux.VerdictAnnotation:          "maybe",
+				konflux.VerdictTimestampAnnotation: "2025-01-01T00:00:00Z",
+			}, false, false),
+			Entry("with an invalid timestamp", map[string]string{
+				konflux.VerdictAnnotation:          "valid",
+				konflux.VerdictTimestampAnnotation: "yesterday",
+			}, false, false),
+		)
+	})
+})
@@ -0,0 +1,286 @@
+package konflux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/jordigilh/korn/internal"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var snapshotResourceGVR = schema.GroupVersionResource{
+	Group:    "appstudio.redhat.com",
+	Version:  "v1alpha1",
+	Resource: "snapshots",
+}
+
+// SnapshotEvent is reported when a snapshot is created or the status of its tests changes while watching snapshots
+type SnapshotEvent struct {
+	Snapshot applicationapiv1alpha1.Snapshot
+	// TestStatus is the reason of the snapshot's AppStudioTestSucceeded condition
+	TestStatus string
+	// Verdict is the result of validating the snapshot as a candidate for release. It is only populated when
+	// k.Candidate is set and the snapshot's tests have finished.
+	Verdict *Verdict
+}
+
+// WatchSnapshots watches the push event snapshots of the application, or of the namespace when no application is
+// given, and calls handler every time a snapshot is created or the status of its tests changes. When k.Candidate is
+// set, the snapshots are validated as candidates for release once their tests finish. Watching stops when the context
+// is done or handler returns true. Snapshots built from a branch other than the release branch are ignored.
+func (k Korn) WatchSnapshots(ctx context.Context, handler func(SnapshotEvent) bool) error {
+	var comps []applicationapiv1alpha1.Component
+	var validate SnapshotValidator
+	if len(k.ApplicationName) > 0 {
+		var err error
+		comps, err = k.getComponentsForRelease()
+		if err != nil {
+			return err
+		}
+		if k.Candidate {
+			k.PodClient = internal.NewMemoizedImageClient(k.PodClient)
+			validate, err = k.getSnapshotValidator()
+			if err != nil {
+				return err
+			}
+		}
+	} else if k.Candidate {
+		return errors.New("application name is required to validate snapshot candidates")
+	}
+	selector, err := componentSelector(matchingLabelsPushEventType, comps)
+	if err != nil {
+		return err
+	}
+	branch, err := k.getReleaseBranch()
+	if err != nil {
+		return err
+	}
+	if k.Candidate {
+		k.Branch = branch
+		k, err = k.withVerdictOptions()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only report the changes that happen after the command starts
+	list := applicationapiv1alpha1.SnapshotList{}
+	if err := k.KubeClient.List(ctx, &list, client.InNamespace(k.Namespace), client.MatchingLabelsSelector{Selector: selector}, client.Limit(1)); err != nil {
+		return err
+	}
+	statuses := map[string]string{}
+	return k.watchSnapshots(ctx, selector, list.ResourceVersion, nil, func(s applicationapiv1alpha1.Snapshot) (bool, error) {
+		if !isSnapshotFromBranch(s, branch) {
+			return false, nil
+		}
+		status := SnapshotTestStatus(s)
+		if prev, ok := statuses[s.Name]; ok && prev == status {
+			return false, nil
+		}
+		statuses[s.Name] = status
+		event := SnapshotEvent{Snapshot: s, TestStatus: status}
+		if k.Candidate && hasSnapshotCompletedSuccessfully(s) {
+			v, err := k.validateSnapshotCandidacy(validate, s)
+			if err != nil {
+				return false, err
+			}
+			if k.RecordVerdicts {
+				k.recordVerdict(s, v)
+			}
+			event.Verdict = &v
+		}
+		return handler(event), nil
+	})
+}
+
+// watchSnapshots watches the snapshots in the namespace that match the selector, starting from the resource version,
+// and calls handler for each snapshot added or modified until it returns true or an error, or the context is done.
+// The watch is resumed when the API server closes it. When the resource version has expired, the snapshots are listed
+// again to resume from a new one, and the existing snapshots, the ones already reported and the ones created since the
+// watch started are passed to handler again, since their changes in between were not watched.
+func (k Korn) watchSnapshots(ctx context.Context, selector labels.Selector, resourceVersion string, existing []applicationapiv1alpha1.Snapshot, handler func(applicationapiv1alpha1.Snapshot) (bool, error)) error {
+	start := time.Now().Truncate(time.Second)
+	tracked := map[string]bool{}
+	for _, s := range existing {
+		tracked[s.Name] = true
+	}
+	// relist returns the resource version to resume the watch from after passing the tracked snapshots to handler
+	relist := func() (string, bool, error) {
+		list := applicationapiv1alpha1.SnapshotList{}
+		if err := k.KubeClient.List(ctx, &list, client.InNamespace(k.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return "", true, err
+		}
+		for _, s := range list.Items {
+			if !tracked[s.Name] && s.CreationTimestamp.Time.Before(start) {
+				continue
+			}
+			tracked[s.Name] = true
+			if stop, err := handler(s); stop || err != nil {
+				return "", true, err
+			}
+		}
+		return list.ResourceVersion, false, nil
+	}
+	for {
+		w, err := k.DynamicClient.Resource(snapshotResourceGVR).Namespace(k.Namespace).Watch(ctx, v1.ListOptions{
+			LabelSelector:   selector.String(),
+			ResourceVersion: resourceVersion,
+		})
+		if isResourceVersionExpired(err) {
+			logrus.Debugf("resource version %s of the snapshots in namespace %s expired, listing them again", resourceVersion, k.Namespace)
+			var done bool
+			if resourceVersion, done, err = relist(); done || err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		done, err := func() (bool, error) {
+			defer w.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return true, nil
+				case event, ok := <-w.ResultChan():
+					if !ok {
+						return false, nil
+					}
+					if event.Type == watch.Error {
+						return true, apierrors.FromObject(event.Object)
+					}
+					if event.Type != watch.Added && event.Type != watch.Modified {
+						continue
+					}
+					snapshot := applicationapiv1alpha1.Snapshot{}
+					b, err := json.Marshal(event.Object)
+					if err != nil {
+						return true, err
+					}
+					if err := json.Unmarshal(b, &snapshot); err != nil {
+						return true, err
+					}
+					resourceVersion = snapshot.ResourceVersion
+					tracked[snapshot.Name] = true
+					logrus.Debugf("[%s] Snapshot: %s", event.Type, snapshot.Name)
+					stop, err := handler(snapshot)
+					if stop || err != nil {
+						return true, err
+					}
+				}
+			}
+		}()
+		if isResourceVersionExpired(err) {
+			logrus.Debugf("resource version %s of the snapshots in namespace %s expired, listing them again", resourceVersion, k.Namespace)
+			if resourceVersion, done, err = relist(); done || err != nil {
+				return err
+			}
+			continue
+		}
+		if done || err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		logrus.Debugf("watch for snapshots in namespace %s closed, resuming from resource version %s", k.Namespace, resourceVersion)
+	}
+}
+
+// WaitForSnapshot waits up to k.WaitForTimeout minutes for a push event snapshot of the application built from the
+// commit k.SHA to exist and finish its integration tests. It returns an error if the tests fail or the timeout is
+// reached. When several snapshots exist for the commit, a successful one is preferred over the most recent. With
+// k.WaitForRetest, failed tests don't end the wait: the last failure is returned together with the timeout instead.
+func (k Korn) WaitForSnapshot(ctx context.Context) (*applicationapiv1alpha1.Snapshot, error) {
+	if len(k.SHA) == 0 {
+		return nil, errors.New("commit SHA is required")
+	}
+	if len(k.ApplicationName) == 0 {
+		return nil, errors.New("application name is required")
+	}
+	matchingLabels := maps.Clone(matchingLabelsPushEventType)
+	matchingLabels["appstudio.openshift.io/application"] = k.ApplicationName
+	matchingLabels["pac.test.appstudio.openshift.io/sha"] = k.SHA
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(k.WaitForTimeout)*time.Minute)
+	defer cancel()
+	list := applicationapiv1alpha1.SnapshotList{}
+	if err := k.KubeClient.List(ctx, &list, client.InNamespace(k.Namespace), matchingLabels); err != nil {
+		return nil, err
+	}
+	if i := slices.IndexFunc(list.Items, hasSnapshotCompletedSuccessfully); i >= 0 {
+		return &list.Items[i], nil
+	}
+	// failure is the error of the last snapshot whose tests failed
+	var failure error
+	if len(list.Items) > 0 {
+		latest := slices.MaxFunc(list.Items, func(a, b applicationapiv1alpha1.Snapshot) int {
+			return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+		})
+		if failure = checkSnapshotTestsFailed(latest); failure != nil {
+			if !k.WaitForRetest {
+				return nil, failure
+			}
+			logrus.Warnf("%v, waiting for a retest or a new build", failure)
+		}
+	}
+
+	var snapshot *applicationapiv1alpha1.Snapshot
+	err := k.watchSnapshots(ctx, labels.SelectorFromSet(labels.Set(matchingLabels)), list.ResourceVersion, list.Items, func(s applicationapiv1alpha1.Snapshot) (bool, error) {
+		if hasSnapshotCompletedSuccessfully(s) {
+			snapshot = &s
+			return true, nil
+		}
+		if err := checkSnapshotTestsFailed(s); err != nil {
+			if !k.WaitForRetest {
+				return true, err
+			}
+			logrus.Warnf("%v, waiting for a retest or a new build", err)
+			failure = err
+			return false, nil
+		}
+		logrus.Debugf("Snapshot %s/%s has not finished its tests yet: %s", s.Namespace, s.Name, SnapshotTestStatus(s))
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err := fmt.Errorf("timeout of %d minute(s) reached waiting for snapshot with SHA %s in application %s/%s", k.WaitForTimeout, k.SHA, k.Namespace, k.ApplicationName)
+			if failure != nil {
+				return nil, fmt.Errorf("%w: %w", err, failure)
+			}
+			return nil, err
+		}
+		return nil, ctx.Err()
+	}
+	return snapshot, nil
+}
+
+// isResourceVersionExpired returns true when the error reports that the resource version to watch from is too old
+func isResourceVersionExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
+
+// checkSnapshotTestsFailed returns an error when the integration tests of the snapshot have finished unsuccessfully
+func checkSnapshotTestsFailed(snapshot applicationapiv1alpha1.Snapshot) error {
+	c := getConditionByType("AppStudioTestSucceeded", snapshot.Status.Conditions)
+	if c == nil || c.Status != v1.ConditionFalse {
+		return nil
+	}
+	return fmt.Errorf("integration tests of snapshot %s/%s failed with reason %s: %s", snapshot.Namespace, snapshot.Name, c.Reason, c.Message)
+}
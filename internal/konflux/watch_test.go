@@ -0,0 +1,321 @@
+package konflux_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jordigilh/korn/internal/konflux"
+	"github.com/jordigilh/korn/testutils"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	dfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Watching snapshots", func() {
+	var (
+		kornInstance *konflux.Korn
+		// fw serves the first watch and resumed the ones that follow
+		fw, resumed *watch.FakeWatcher
+		events      []konflux.SnapshotEvent
+	)
+
+	toUnstructured := func(s *applicationapiv1alpha1.Snapshot) *unstructured.Unstructured {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(s)
+		Expect(err).ToNot(HaveOccurred())
+		return &unstructured.Unstructured{Object: m}
+	}
+
+	BeforeEach(func() {
+		events = []konflux.SnapshotEvent{}
+		dynamicClient := dfake.NewSimpleDynamicClient(runtime.NewScheme())
+		fw, resumed = watch.NewFake(), watch.NewFake()
+		var watches atomic.Int32
+		dynamicClient.PrependWatchReactor("snapshots", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			if watches.Add(1) == 1 {
+				return true, fw, nil
+			}
+			return true, resumed, nil
+		})
+		kornInstance = &konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			DynamicClient:   dynamicClient,
+			PodClient:       &mockImageClientValid{},
+			KubeClient: fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(
+				newNamespace(testutils.TestNamespace),
+				testutils.NewOperatorApplication(testutils.TestAppName, testutils.TestNamespace),
+				testutils.NewBundleComponent(testutils.BundleComponentName, testutils.TestNamespace, testutils.TestAppName),
+				testutils.NewControllerComponent(testutils.ControllerComponentName, testutils.TestNamespace, testutils.TestAppName),
+			).Build(),
+		}
+	})
+
+	It("should report the snapshots whose test status changes until the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		var reported atomic.Int32
+		go func() {
+			defer GinkgoRecover()
+			fw.Add(toUnstructured(newPendingSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			// Changes that don't affect the status of the tests are not reported
+			fw.Modify(toUnstructured(newPendingSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			fw.Modify(toUnstructured(newFailedSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			// Deleted snapshots are ignored
+			fw.Delete(toUnstructured(newFailedSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			fw.Add(toUnstructured(newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			Eventually(reported.Load).Should(BeEquivalentTo(3))
+			cancel()
+		}()
+
+		err := kornInstance.WatchSnapshots(ctx, func(e konflux.SnapshotEvent) bool {
+			events = append(events, e)
+			reported.Add(1)
+			return false
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Snapshot.Name).To(Equal(pendingSnapshotName))
+		Expect(events[0].TestStatus).To(Equal("InProgress"))
+		Expect(events[1].TestStatus).To(Equal("Failed"))
+		Expect(events[2].Snapshot.Name).To(Equal(finishedSnapshotName))
+		Expect(events[2].TestStatus).To(Equal("Finished"))
+		for _, e := range events {
+			Expect(e.Verdict).To(BeNil())
+		}
+	})
+
+	It("should validate the snapshots once their tests finish when looking for candidates", func() {
+		kornInstance.Candidate = true
+		go func() {
+			fw.Add(toUnstructured(newPendingSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			fw.Modify(toUnstructured(newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+		}()
+
+		err := kornInstance.WatchSnapshots(context.Background(), func(e konflux.SnapshotEvent) bool {
+			events = append(events, e)
+			return e.Verdict != nil && e.Verdict.Valid
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Verdict).To(BeNil())
+		Expect(events[1].Verdict).ToNot(BeNil())
+		Expect(events[1].Verdict.Valid).To(BeTrue())
+	})
+
+	It("should fail when the watch reports an error", func() {
+		go func() {
+			fw.Error(&apierrors.NewForbidden(schema.GroupResource{Resource: "snapshots"}, "", errors.New("access denied")).ErrStatus)
+		}()
+
+		err := kornInstance.WatchSnapshots(context.Background(), func(e konflux.SnapshotEvent) bool { return false })
+
+		Expect(err).To(HaveOccurred())
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+	})
+
+	DescribeTable("should list the snapshots again and resume the watch when the resource version expires",
+		func(expired *metav1.Status) {
+			go func() {
+				defer GinkgoRecover()
+				// A snapshot created while the watch was not running
+				created := newPendingSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+				created.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Minute))
+				Expect(kornInstance.KubeClient.Create(context.Background(), created)).To(Succeed())
+				fw.Error(expired)
+				resumed.Add(toUnstructured(newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			}()
+
+			err := kornInstance.WatchSnapshots(context.Background(), func(e konflux.SnapshotEvent) bool {
+				events = append(events, e)
+				return e.Snapshot.Name == finishedSnapshotName
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Snapshot.Name).To(Equal(pendingSnapshotName))
+			Expect(events[1].Snapshot.Name).To(Equal(finishedSnapshotName))
+		},
+		Entry("with a gone error", &apierrors.NewGone("too old resource version").ErrStatus),
+		Entry("with an expired error", &apierrors.NewResourceExpired("too old resource version").ErrStatus),
+	)
+
+	It("should not report the snapshots created before the watch started when it is resumed", func() {
+		old := newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		old.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		Expect(kornInstance.KubeClient.Create(context.Background(), old)).To(Succeed())
+		go func() {
+			fw.Error(&apierrors.NewGone("too old resource version").ErrStatus)
+			resumed.Add(toUnstructured(newPendingSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+		}()
+
+		err := kornInstance.WatchSnapshots(context.Background(), func(e konflux.SnapshotEvent) bool {
+			events = append(events, e)
+			return true
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Snapshot.Name).To(Equal(pendingSnapshotName))
+	})
+
+	It("should require the application to validate candidates", func() {
+		kornInstance.ApplicationName = ""
+		kornInstance.Candidate = true
+
+		err := kornInstance.WatchSnapshots(context.Background(), func(e konflux.SnapshotEvent) bool { return true })
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("application name is required"))
+	})
+})
+
+var _ = Describe("Waiting for a snapshot by commit SHA", func() {
+	var (
+		fw            *watch.FakeWatcher
+		dynamicClient *dfake.FakeDynamicClient
+	)
+
+	toUnstructured := func(s *applicationapiv1alpha1.Snapshot) *unstructured.Unstructured {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(s)
+		Expect(err).ToNot(HaveOccurred())
+		return &unstructured.Unstructured{Object: m}
+	}
+
+	newKorn := func(objs ...runtime.Object) konflux.Korn {
+		return konflux.Korn{
+			Namespace:       testutils.TestNamespace,
+			ApplicationName: testutils.TestAppName,
+			SHA:             testSHA,
+			WaitForTimeout:  1,
+			DynamicClient:   dynamicClient,
+			KubeClient:      fake.NewClientBuilder().WithScheme(createFakeScheme()).WithRuntimeObjects(append(objs, newNamespace(testutils.TestNamespace))...).Build(),
+		}
+	}
+
+	BeforeEach(func() {
+		dynamicClient = dfake.NewSimpleDynamicClient(runtime.NewScheme())
+		fw = watch.NewFake()
+		dynamicClient.PrependWatchReactor("snapshots", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			return true, fw, nil
+		})
+	})
+
+	It("should return the existing snapshot when its tests have finished", func() {
+		k := newKorn(newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName))
+
+		s, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Name).To(Equal(finishedSnapshotName))
+	})
+
+	It("should fail when the tests of the existing snapshot have failed", func() {
+		k := newKorn(newFailedSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName))
+
+		_, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).To(MatchError(ContainSubstring("integration tests of snapshot")))
+	})
+
+	It("should report the failed tests of the existing snapshot when the timeout is reached waiting for a retest", func() {
+		k := newKorn(newFailedSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName))
+		k.WaitForTimeout = 0
+		k.WaitForRetest = true
+
+		_, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).To(MatchError(ContainSubstring("timeout of 0 minute(s) reached")))
+		Expect(err).To(MatchError(ContainSubstring("integration tests of snapshot")))
+	})
+
+	It("should wait for the snapshot to be created and finish its tests", func() {
+		k := newKorn()
+		go func() {
+			fw.Add(toUnstructured(newPendingSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			fw.Modify(toUnstructured(newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+		}()
+
+		s, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Name).To(Equal(finishedSnapshotName))
+	})
+
+	It("should keep waiting for a retest after the tests of a snapshot fail", func() {
+		k := newKorn(newFailedSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName))
+		k.WaitForRetest = true
+		go func() {
+			fw.Modify(toUnstructured(newPendingSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			fw.Modify(toUnstructured(newFailedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+			fw.Modify(toUnstructured(newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+		}()
+
+		s, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Name).To(Equal(finishedSnapshotName))
+	})
+
+	It("should fail when the tests of the new snapshot fail", func() {
+		k := newKorn(newPendingSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName))
+		go func() {
+			fw.Modify(toUnstructured(newFailedSnapshot(pendingSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)))
+		}()
+
+		_, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).To(MatchError(ContainSubstring("failed with reason Failed")))
+	})
+
+	It("should find the snapshot that finished its tests while the watch was resumed", func() {
+		pending := newPendingSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName)
+		pending.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		k := newKorn(pending)
+		go func() {
+			defer GinkgoRecover()
+			// Wait for the watch to start before the tests finish
+			fw.Modify(toUnstructured(pending))
+			finished := applicationapiv1alpha1.Snapshot{}
+			Expect(k.KubeClient.Get(context.Background(), types.NamespacedName{Namespace: testutils.TestNamespace, Name: finishedSnapshotName}, &finished)).To(Succeed())
+			finished.Status = newFinishedSnapshot(finishedSnapshotName, testutils.TestNamespace, testutils.TestAppName, testutils.BundleComponentName).Status
+			Expect(k.KubeClient.Update(context.Background(), &finished)).To(Succeed())
+			fw.Error(&apierrors.NewGone("too old resource version").ErrStatus)
+		}()
+
+		s, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Name).To(Equal(finishedSnapshotName))
+	})
+
+	It("should fail when the timeout is reached", func() {
+		k := newKorn()
+		k.WaitForTimeout = 0
+
+		_, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).To(MatchError(ContainSubstring("timeout of 0 minute(s) reached")))
+	})
+
+	It("should require the commit SHA", func() {
+		k := newKorn()
+		k.SHA = ""
+
+		_, err := k.WaitForSnapshot(context.Background())
+
+		Expect(err).To(MatchError("commit SHA is required"))
+	})
+})
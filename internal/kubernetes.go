@@ -23,6 +23,7 @@ var (
 	NamespaceCtxType  ContextType = "namespace"
 	GitCliCtxType     ContextType = "gitCli"
 	DynamicCliCtxType ContextType = "dynamicCli"
+	VersionCtxType    ContextType = "version"
 )
 
 func GetDefaultKubeconfigPath() string {
@@ -7,7 +7,13 @@ import (
 	"os"
 
 	"github.com/jordigilh/korn/cmd/create"
+	"github.com/jordigilh/korn/cmd/describe"
+	"github.com/jordigilh/korn/cmd/diff"
+	"github.com/jordigilh/korn/cmd/doctor"
 	"github.com/jordigilh/korn/cmd/get"
+	"github.com/jordigilh/korn/cmd/onboard"
+	"github.com/jordigilh/korn/cmd/snapshot"
+	"github.com/jordigilh/korn/cmd/status"
 	"github.com/jordigilh/korn/cmd/waitfor"
 	"github.com/jordigilh/korn/internal"
 	"github.com/sirupsen/logrus"
@@ -16,6 +22,7 @@ import (
 
 var (
 	debug   bool
+	noCache bool
 	version string = "dev" // Set via ldflags during build
 )
 
@@ -49,6 +56,16 @@ func main() {
 				Usage:       "Enable debug mode",
 				Destination: &debug,
 			},
+			&cli.BoolFlag{
+				Name:        "no-cache",
+				Usage:       "Inspect the container images instead of using the cached image metadata",
+				Destination: &noCache,
+			},
+			&cli.StringFlag{
+				Name:  "cache-dir",
+				Usage: "Directory where the metadata of the inspected container images is cached. Example: -cache-dir ~/.cache/korn/images",
+				Value: internal.GetDefaultImageCacheDir(),
+			},
 			&cli.BoolFlag{
 				Name:    "version",
 				Aliases: []string{"v"},
@@ -73,6 +90,9 @@ func main() {
 			if err != nil {
 				return nil, err
 			}
+			if !noCache {
+				podClient = internal.NewCachedImageClient(podClient, cmd.String("cache-dir"))
+			}
 			kubeClient, err := internal.GetClient(cmd.String("kubeconfig"))
 			if err != nil {
 				return nil, err
@@ -82,16 +102,23 @@ func main() {
 				return nil, err
 			}
 			ctx = context.WithValue(ctx, internal.NamespaceCtxType, cmd.String("namespace"))
-			ctx = context.WithValue(ctx, internal.PodmanCliCtxType, podClient)
+			ctx = context.WithValue(ctx, internal.PodmanCliCtxType, internal.NewMemoizedImageClient(podClient))
 			ctx = context.WithValue(ctx, internal.GitCliCtxType, internal.NewGitClient())
 			ctx = context.WithValue(ctx, internal.DynamicCliCtxType, dynamicClient)
 			ctx = context.WithValue(ctx, internal.KubeCliCtxType, kubeClient)
+			ctx = context.WithValue(ctx, internal.VersionCtxType, version)
 			return ctx, nil
 		},
 		Commands: []*cli.Command{
 			get.Command(),
 			create.Command(),
-			waitfor.Command()},
+			describe.Command(),
+			diff.Command(),
+			doctor.Command(),
+			onboard.Command(),
+			status.Command(),
+			waitfor.Command(),
+			snapshot.Command()},
 	}
 
 	if err := cmd.Run(context.Background(), os.Args); err != nil {
@@ -9,12 +9,14 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/blang/semver/v4"
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
 	"github.com/containers/podman/v5/pkg/inspect"
 	"github.com/jordigilh/korn/internal"
 	"github.com/jordigilh/korn/internal/konflux"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	releaseapiv1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	tektonutils "github.com/konflux-ci/release-service/tekton/utils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -25,15 +27,17 @@ import (
 
 // Test constants
 const (
-	TestNamespace           = "test-namespace"
-	TestAppName             = "test-app"
-	OtherAppName            = "other-app"
-	TestComponentName       = "test-component"
-	TestReleaseName         = "test-release"
-	TestSnapshotName        = "test-snapshot"
-	TestReleasePlan         = "test-releaseplan"
-	BundleComponentName     = "bundle-component"
-	ControllerComponentName = "controller-component"
+	TestNamespace            = "test-namespace"
+	TestAppName              = "test-app"
+	OtherAppName             = "other-app"
+	TestComponentName        = "test-component"
+	TestReleaseName          = "test-release"
+	TestSnapshotName         = "test-snapshot"
+	TestReleasePlan          = "test-releaseplan"
+	TestManagedNamespace     = "test-managed-namespace"
+	TestReleasePlanAdmission = "test-releaseplanadmission"
+	BundleComponentName      = "bundle-component"
+	ControllerComponentName  = "controller-component"
 )
 
 // Konflux label constants for tests
@@ -99,6 +103,15 @@ func NewOperatorApplication(name, namespace string) *applicationapiv1alpha1.Appl
 	})
 }
 
+// NewStreamApplication returns an operator application that releases the version of the operator's stream
+func NewStreamApplication(name, namespace, operator, version string) *applicationapiv1alpha1.Application {
+	return NewApplication(name, namespace, map[string]string{
+		konflux.ApplicationTypeLabel: "operator",
+		konflux.StreamLabel:          operator,
+		konflux.StreamVersionLabel:   version,
+	})
+}
+
 func NewFBCApplication(name, namespace string) *applicationapiv1alpha1.Application {
 	return NewApplication(name, namespace, map[string]string{
 		konflux.ApplicationTypeLabel: "fbc",
@@ -198,6 +211,49 @@ func NewStagingReleasePlan(name, namespace, application string) *releaseapiv1alp
 	})
 }
 
+// NewAdmittedReleasePlan returns a staging release plan matched by an active ReleasePlanAdmission in the managed namespace
+func NewAdmittedReleasePlan(name, namespace, application, admission string) *releaseapiv1alpha1.ReleasePlan {
+	rp := NewStagingReleasePlan(name, namespace, application)
+	rp.Spec.Target = TestManagedNamespace
+	rp.Status.ReleasePlanAdmission = releaseapiv1alpha1.MatchedReleasePlanAdmission{
+		Name:   TestManagedNamespace + "/" + admission,
+		Active: true,
+	}
+	return rp
+}
+
+// ReleasePlanAdmission helpers
+
+// NewReleasePlanAdmission returns an admission in the managed namespace that maps the components of the test snapshot
+func NewReleasePlanAdmission(name string, applications ...string) *releaseapiv1alpha1.ReleasePlanAdmission {
+	return &releaseapiv1alpha1.ReleasePlanAdmission{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         TestManagedNamespace,
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: releaseapiv1alpha1.ReleasePlanAdmissionSpec{
+			Applications: applications,
+			Origin:       TestNamespace,
+			Policy:       "test-policy",
+			Data: &runtime.RawExtension{Raw: []byte(`{"mapping":{"components":[` +
+				`{"name":"` + ControllerComponentName + `","repository":"quay.io/test/controller"},` +
+				`{"name":"` + BundleComponentName + `","repository":"quay.io/test/bundle","tags":["{{ git_sha }}","v1.0"]}],` +
+				`"defaults":{"tags":["latest"]}}}`)},
+			Pipeline: &tektonutils.Pipeline{
+				PipelineRef: tektonutils.PipelineRef{
+					Resolver: "git",
+					Params: []tektonutils.Param{
+						{Name: "url", Value: "https://github.com/konflux-ci/release-service-catalog.git"},
+						{Name: "revision", Value: "production"},
+						{Name: "pathInRepo", Value: "pipelines/managed/rh-advisories/rh-advisories.yaml"},
+					},
+				},
+			},
+		},
+	}
+}
+
 // Snapshot helpers
 func NewSnapshot(name, namespace, application, component, sha string) *applicationapiv1alpha1.Snapshot {
 	return &applicationapiv1alpha1.Snapshot{
@@ -310,7 +366,8 @@ func GetCompleteCreateReleaseTestSet() []runtime.Object {
 		// Snapshot
 		NewTestSnapshot(),
 		// Release Plan
-		NewStagingReleasePlan(TestReleasePlan, TestNamespace, TestAppName),
+		NewAdmittedReleasePlan(TestReleasePlan, TestNamespace, TestAppName, TestReleasePlanAdmission),
+		NewReleasePlanAdmission(TestReleasePlanAdmission, TestAppName),
 	}
 }
 
@@ -328,6 +385,20 @@ func (m *MockImageClient) GetImageData(image string) (*types.ImageInspectReport,
 	}, nil
 }
 
+// MockGitClient returns the same version for every repository and commit
+type MockGitClient struct{}
+
+func (m *MockGitClient) GetVersion(repoURL, commitHash string) (*semver.Version, error) {
+	v := semver.MustParse("1.0.0")
+	return &v, nil
+}
+
+func (m *MockGitClient) GetCommitTitle(repoURL, commitHash string) (string, error) {
+	return "Commit " + commitHash, nil
+}
+
+func (m *MockGitClient) Cleanup() {}
+
 // Test file helpers
 func CreateTempReleaseNotesFile() (string, string, error) {
 	tempDir, err := os.MkdirTemp("", "korn-test")
@@ -364,6 +435,7 @@ type CreateTestSetup struct {
 	*TestSetup
 	DynamicClient dynamic.Interface
 	MockPodClient *MockImageClient
+	MockGitClient *MockGitClient
 	TempDir       string
 	ReleaseNotes  string
 }
@@ -379,6 +451,7 @@ func NewCreateTestSetup(scheme *runtime.Scheme) (*CreateTestSetup, error) {
 	return &CreateTestSetup{
 		TestSetup:     baseSetup,
 		MockPodClient: &MockImageClient{},
+		MockGitClient: &MockGitClient{},
 		TempDir:       tempDir,
 		ReleaseNotes:  releaseNotesFile,
 	}, nil
@@ -387,6 +460,7 @@ func NewCreateTestSetup(scheme *runtime.Scheme) (*CreateTestSetup, error) {
 func (cts *CreateTestSetup) WithKubeClientAndMocks() context.Context {
 	ctx := cts.WithKubeClient()
 	ctx = context.WithValue(ctx, internal.PodmanCliCtxType, cts.MockPodClient)
+	ctx = context.WithValue(ctx, internal.GitCliCtxType, cts.MockGitClient)
 	if cts.DynamicClient != nil {
 		ctx = context.WithValue(ctx, internal.DynamicCliCtxType, cts.DynamicClient)
 	}